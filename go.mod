@@ -9,9 +9,11 @@ require (
 	github.com/rivo/tview v0.0.0-20210909154944-f7430b878d17
 	github.com/spf13/cobra v1.2.1
 	github.com/spf13/pflag v1.0.5
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 	k8s.io/apimachinery v0.23.4
 	k8s.io/cli-runtime v0.23.4
 	k8s.io/client-go v0.23.4
 	k8s.io/kube-openapi v0.0.0-20211115234752-e816edb12b65
 	k8s.io/kubectl v0.23.1
+	sigs.k8s.io/yaml v1.2.0
 )
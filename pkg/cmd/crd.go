@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"kexplain/pkg/mapper"
+	"kexplain/pkg/model"
+)
+
+// getFromCRDFiles loads the schema from CRD manifests in files or directories, or stdin when path is "-".
+func (o *KexplainOptions) getFromCRDFiles(paths []string) (*model.Resources, mapper.Mapper, error) {
+	crds := []*model.CRD{}
+	parse := func(r io.Reader, name string) error {
+		parsed, err := model.ParseCRDs(r)
+		if err != nil {
+			return fmt.Errorf("fail to parse CRDs in %s: %w", name, err)
+		}
+		crds = append(crds, parsed...)
+		return nil
+	}
+	if err := o.walkManifests(paths, parse); err != nil {
+		return nil, nil, err
+	}
+	if len(crds) == 0 {
+		return nil, nil, fmt.Errorf("no CRDs found in %v", paths)
+	}
+
+	doc, err := model.NewDocumentFromCRDs(crds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to convert CRDs: %w", err)
+	}
+	schema, err := model.NewResources(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to get resources from CRDs: %w", err)
+	}
+
+	resources := []mapper.Resource{}
+	for _, crd := range crds {
+		namespaced := crd.Namespaced
+		for _, gvk := range crd.GroupVersionKinds() {
+			resources = append(resources, mapper.Resource{
+				GroupVersionKind: gvk,
+				Plural:           crd.Names.Plural,
+				Singular:         crd.Names.Singular,
+				ShortNames:       crd.Names.ShortNames,
+				Namespaced:       &namespaced,
+			})
+		}
+	}
+	return schema, mapper.NewRawMapper(resources), nil
+}
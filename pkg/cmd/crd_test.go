@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"kexplain/pkg/testutil"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestGetFromCRDFiles(t *testing.T) {
+	cronTab := schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"}
+	tests := []struct {
+		name  string
+		paths []string
+		in    []byte
+	}{
+		{name: "file", paths: []string{testutil.Path("crds.yaml")}},
+		{name: "stdin", paths: []string{"-"}, in: testutil.ReadFile(t, "crds.yaml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &KexplainOptions{IOStreams: genericclioptions.IOStreams{In: bytes.NewReader(tt.in)}}
+			resources, m, err := o.getFromCRDFiles(tt.paths)
+			if err != nil {
+				t.Fatalf("getFromCRDFiles() error = %v", err)
+			}
+			for _, name := range []string{"crontabs", "crontab", "ct", "CronTab"} {
+				if got, err := m.KindFor(schema.GroupVersionResource{Resource: name}); err != nil || got != cronTab {
+					t.Errorf("KindFor(%q) = %v, %v, want %v", name, got, err, cronTab)
+				}
+			}
+			if resources.LookupResource(cronTab) == nil {
+				t.Errorf("LookupResource(%v) = nil", cronTab)
+			}
+		})
+	}
+}
+
+func TestGetFromCRDFilesWithoutCRDs(t *testing.T) {
+	o := &KexplainOptions{}
+	if _, _, err := o.getFromCRDFiles([]string{testutil.Path("deployment.yaml")}); err == nil {
+		t.Error("getFromCRDFiles() error = nil, want an error")
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"kexplain/pkg/mapper"
+	"kexplain/pkg/model"
+	"kexplain/pkg/view"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const diffExample = `
+	# List changed kinds and fields between two k8s versions
+	%[1]s diff --from 1.24 --to 1.27
+
+	# Compare a resource or field side by side
+	%[1]s diff --from 1.24 --to 1.27 hpa.spec --api-version autoscaling/v2
+
+	# Print changes of a resource
+	%[1]s diff --from v1.24.3 --to 1.27 cronjob -o text
+
+	# Compare a local swagger file with a k8s version
+	%[1]s diff --schema-file swagger.json --to 1.27
+`
+
+var (
+	diffFrom = ""
+	diffTo   = ""
+)
+
+func newCmdDiff(o *KexplainOptions, cmdName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff (--from <k8s-version> | --schema-file <file>) --to <k8s-version> [<type>[.<version>][.<group>][.<fieldName>]]",
+		Short: "Show added, removed and retyped fields between two k8s versions",
+		Long: `Show added (+), removed (-) and retyped (~) fields, and changed descriptions (*) between two k8s versions.
+
+Schemas are fetched from GitHub and cached locally. Versions are like "1.24" for the release branch, or "1.24.3" for the tag.
+The old schema is read from local files instead of --from with --schema-file.
+Changes of all kinds are printed without a resource. Otherwise the docs of both versions are shown side by side.`,
+		Example:      fmt.Sprintf(diffExample, cmdName),
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if (diffFrom == "") == (len(schemaFiles) == 0) || diffTo == "" {
+				return fmt.Errorf("--to and either --from or --schema-file are required")
+			}
+			if output != "" && output != outputText {
+				return fmt.Errorf("unsupported output format %q, only %q is supported", output, outputText)
+			}
+			fromSchema, fromMapper, err := o.getDiffFrom()
+			if err != nil {
+				return err
+			}
+			toSchema, toMapper, err := getFromRemote(diffTo)
+			if err != nil {
+				return fmt.Errorf("fail to get schema of %s: %w", diffTo, err)
+			}
+			if len(args) == 0 {
+				return view.PrintKindDiffs(o.Out, model.DiffResources(fromSchema, toSchema))
+			}
+			return o.diffResource(args[0], fromSchema, toSchema, fromMapper, toMapper)
+		},
+	}
+	cmd.Flags().StringVar(&diffFrom, "from", "", "the old k8s version")
+	cmd.Flags().StringVar(&diffTo, "to", "", "the new k8s version")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "\"text\" prints changes instead of the interactive view. Used by default when stdout is not a terminal")
+	return cmd
+}
+
+// getDiffFrom returns the old schema of --from, or the one of --schema-file.
+// diffFrom is set to the files in the latter case, which is shown as the old version.
+func (o *KexplainOptions) getDiffFrom() (*model.Resources, mapper.Mapper, error) {
+	if len(schemaFiles) == 0 {
+		fromSchema, fromMapper, err := getFromRemote(diffFrom)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to get schema of %s: %w", diffFrom, err)
+		}
+		return fromSchema, fromMapper, nil
+	}
+	diffFrom = strings.Join(schemaFiles, ",")
+	return o.getFromFiles(schemaFiles)
+}
+
+// diffResource shows changes of the resource or field in arg
+func (o *KexplainOptions) diffResource(arg string, fromSchema, toSchema *model.Resources, fromMapper, toMapper mapper.Mapper) error {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return err
+	}
+	// kinds are resolved in each schema, whose preferred versions may differ like batch/v1beta1 and batch/v1 CronJob
+	oldGVK, fieldsPath, fromErr := mapper.KindForArg(fromMapper, arg, gv)
+	newGVK, newFieldsPath, toErr := mapper.KindForArg(toMapper, arg, gv)
+	if fromErr != nil && toErr != nil {
+		return toErr
+	}
+	var oldSchema, newSchema proto.Schema
+	if fromErr == nil {
+		oldSchema = fromSchema.LookupResource(oldGVK)
+	}
+	if toErr == nil {
+		newSchema = toSchema.LookupResource(newGVK)
+		fieldsPath = newFieldsPath
+	}
+	switch {
+	case oldSchema == nil && newSchema == nil:
+		return fmt.Errorf("%s is found in neither %s nor %s", arg, diffFrom, diffTo)
+	case oldSchema == nil:
+		return view.PrintKindDiffs(o.Out, []model.KindDiff{{GroupVersionKind: newGVK, Added: true}})
+	case newSchema == nil:
+		return view.PrintKindDiffs(o.Out, []model.KindDiff{{GroupVersionKind: oldGVK, Removed: true}})
+	}
+	oldDoc, err := model.NewDoc(oldSchema, fieldsPath, oldGVK)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, diffFrom)
+	}
+	newDoc, err := model.NewDoc(newSchema, fieldsPath, newGVK)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, diffTo)
+	}
+	changes := model.DiffDocs(oldDoc, newDoc)
+
+	if output == outputText || !isTerminal(o.Out) {
+		if len(changes) == 0 {
+			_, err := fmt.Fprintf(o.Out, "No changes in %s\n", newDoc.GetFullPath())
+			return err
+		}
+		diff := model.KindDiff{GroupVersionKind: newGVK, Changes: changes}
+		if oldGVK.GroupVersion() != newGVK.GroupVersion() {
+			diff.OldGroupVersion = oldGVK.GroupVersion()
+		}
+		return view.PrintKindDiffs(o.Out, []model.KindDiff{diff})
+	}
+	app := tview.NewApplication()
+	diffView := view.NewDiffView(changes, oldDoc, newDoc)
+	diffView.SetStopFn(func() { app.Stop() })
+	diffView.SetVersions(diffFrom, diffTo)
+	if err := app.SetRoot(diffView, true).Run(); err != nil {
+		fmt.Printf("failed to render: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"kexplain/pkg/testutil"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestDiffResource(t *testing.T) {
+	o := &KexplainOptions{}
+	fromSchema, fromMapper, err := o.getFromFiles([]string{testutil.Path("cronjob-v1beta1.yaml")})
+	if err != nil {
+		t.Fatalf("getFromFiles() error = %v", err)
+	}
+	toSchema, toMapper, err := o.getFromFiles([]string{testutil.Path("cronjob-v1.yaml")})
+	if err != nil {
+		t.Fatalf("getFromFiles() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		arg        string
+		apiVersion string
+		want       string
+	}{
+		{
+			name: "preferred versions",
+			arg:  "cronjobs",
+			want: "~ CronJob (batch/v1beta1 -> batch/v1)\n    + spec.timeZone <string>\n",
+		},
+		{
+			name: "field",
+			arg:  "cronjobs.spec.schedule",
+			want: "No changes in cronjob.spec.schedule\n",
+		},
+		{
+			name:       "api version",
+			arg:        "cronjobs",
+			apiVersion: "batch/v1",
+			want:       "+ CronJob (batch/v1)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiVersion = tt.apiVersion
+			defer func() { apiVersion = "" }()
+			out := &bytes.Buffer{}
+			o := &KexplainOptions{IOStreams: genericclioptions.IOStreams{Out: out}}
+			if err := o.diffResource(tt.arg, fromSchema, toSchema, fromMapper, toMapper); err != nil {
+				t.Fatalf("diffResource() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("diffResource() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
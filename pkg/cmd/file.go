@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"kexplain/pkg/mapper"
+	"kexplain/pkg/model"
+	"os"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+)
+
+// getFromFiles loads the schema from local swagger or OpenAPI v3 files, or stdin when path is "-".
+// The mapper is built from the document itself, so neither network nor kubeconfig is needed.
+func (o *KexplainOptions) getFromFiles(paths []string) (*model.Resources, mapper.Mapper, error) {
+	specs := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		var data []byte
+		var err error
+		if path == "-" {
+			data, err = io.ReadAll(o.In)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to read schema file: %w", err)
+		}
+		specs = append(specs, data)
+	}
+
+	// the version is detected per file, a v2 file among v3 ones would be parsed as v3 without any kind
+	v2, v3 := "", ""
+	for i, spec := range specs {
+		if model.IsV3(spec) {
+			v3 = paths[i]
+		} else {
+			v2 = paths[i]
+		}
+	}
+	if v2 != "" && v3 != "" {
+		return nil, nil, fmt.Errorf("schema files can't mix OpenAPI v2 and v3, %s is v2 but %s is v3", v2, v3)
+	}
+
+	var doc *openapi_v2.Document
+	var err error
+	if v3 != "" {
+		doc, err = model.NewDocumentFromV3(specs...)
+	} else if len(specs) > 1 {
+		return nil, nil, fmt.Errorf("only one swagger file is allowed for OpenAPI v2")
+	} else {
+		doc, err = openapi_v2.ParseDocument(specs[0])
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to parse schema file: %w", err)
+	}
+	schema, err := model.NewResources(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to get resources from schema file: %w", err)
+	}
+	o.version = doc.GetInfo().GetVersion()
+
+	return schema, mapper.NewRawMapper(mapper.ResourcesFromDocument(doc, schema.ListResources())), nil
+}
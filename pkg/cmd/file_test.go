@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"kexplain/pkg/testutil"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestGetFromFiles(t *testing.T) {
+	deploy := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	tests := []struct {
+		name    string
+		paths   []string
+		in      []byte
+		version string
+	}{
+		{name: "swagger file", paths: []string{testutil.Path("deployment.yaml")}, version: "v1"},
+		{name: "swagger from stdin", paths: []string{"-"}, in: testutil.ReadFile(t, "deployment.yaml"), version: "v1"},
+		{name: "v3 files", paths: []string{testutil.Path("v3-apps.json"), testutil.Path("v3-core.yaml")}, version: "v1.27.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &KexplainOptions{IOStreams: genericclioptions.IOStreams{In: bytes.NewReader(tt.in)}}
+			resources, m, err := o.getFromFiles(tt.paths)
+			if err != nil {
+				t.Fatalf("getFromFiles() error = %v", err)
+			}
+			gvk, err := m.KindFor(schema.GroupVersionResource{Resource: "deployments"})
+			if err != nil {
+				t.Fatalf("KindFor() error = %v", err)
+			}
+			if gvk != deploy {
+				t.Errorf("KindFor() = %v, want %v", gvk, deploy)
+			}
+			if resources.LookupResource(gvk) == nil {
+				t.Errorf("LookupResource(%v) = nil", gvk)
+			}
+			if o.version != tt.version {
+				t.Errorf("version = %q, want %q", o.version, tt.version)
+			}
+		})
+	}
+}
+
+func TestGetFromFilesPreferredKind(t *testing.T) {
+	o := &KexplainOptions{}
+	_, m, err := o.getFromFiles([]string{testutil.Path("kinds.yaml")})
+	if err != nil {
+		t.Fatalf("getFromFiles() error = %v", err)
+	}
+	tests := map[string]schema.GroupVersionKind{
+		"events":    {Version: "v1", Kind: "Event"},
+		"ingresses": {Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	}
+	for resource, want := range tests {
+		if got, err := m.KindFor(schema.GroupVersionResource{Resource: resource}); err != nil || got != want {
+			t.Errorf("KindFor(%q) = %v, %v, want %v", resource, got, err, want)
+		}
+	}
+}
+
+func TestGetFromFilesError(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "not found", paths: []string{testutil.Path("not-found.yaml")}},
+		{name: "several swagger files", paths: []string{testutil.Path("deployment.yaml"), testutil.Path("deployment.yaml")}},
+		{name: "v2 after v3", paths: []string{testutil.Path("v3-apps.json"), testutil.Path("deployment.yaml")}},
+		{name: "v3 after v2", paths: []string{testutil.Path("deployment.yaml"), testutil.Path("v3-apps.json")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &KexplainOptions{}
+			if _, _, err := o.getFromFiles(tt.paths); err == nil {
+				t.Error("getFromFiles() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"kexplain/pkg/mapper"
 	"kexplain/pkg/model"
 	"kexplain/pkg/version"
@@ -9,18 +11,22 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/rivo/tview"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/kube-openapi/pkg/util/proto"
-	"k8s.io/kubectl/pkg/util/openapi"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	defaultKubeTimeout = "5s"
+
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
 )
 
 var (
@@ -29,16 +35,43 @@ var (
 Use "kubectl api-resources" for a complete list of supported resources.
 
 Global flags are from "kubectl options", but "--request-timeout" is changed to 5s by default. Remote doc like GitHub will be used
-when k8s server is not accessible.
+when k8s server is not accessible. Use "--schema-file" to read the doc from a local swagger file without accessing the network.
 `
-	cliUsage = `%[1]s <type>.<fieldName>[.<fieldName>]`
+	cliUsage = `%[1]s [<type>[.<version>][.<group>][.<fieldName>]] [<other-type>[.<fieldName>]]`
 
 	cliExample = `
+	# Browse all resources, and get the documentation of the selected one
+	%[1]s
+
 	# Get the documentation of the resource and its fields
 	%[1]s pod
 
 	# Get the documentation of a specific field of a resource
 	%[1]s pod.spec.containers
+
+	# Get the documentation of a resource in a specific group or version
+	%[1]s deployments.apps.spec
+	%[1]s ingresses.v1.networking.k8s.io
+	%[1]s hpa.spec --api-version autoscaling/v2
+
+	# Use a local swagger file, or "-" for stdin
+	%[1]s --schema-file swagger.json deploy.spec
+
+	# Explain CRDs in files or directories without k8s server
+	%[1]s --crd-file crds/ mywidget.spec
+
+	# Compare two docs side by side, or the same doc in another k8s version
+	%[1]s pod.spec podtemplate.template.spec
+	%[1]s cronjob.spec --other-k8s-version 1.27
+
+	# Get all fields of a resource as a tree
+	%[1]s deploy.spec --recursive
+
+	# Print the documentation as plain text, which is the default when the output is not a terminal
+	%[1]s pod.spec -o text --width 120 | grep -A 3 hostNetwork
+
+	# Print the documentation as JSON or YAML for tools
+	%[1]s pod.spec -o json
 `
 
 	versionTemplate = `%[1]s {{printf "version %%s" .Version}}
@@ -46,16 +79,26 @@ when k8s server is not accessible.
 )
 
 var (
-	debug      = false
-	k8sVersion = ""
-	remote     = false
+	debug       = false
+	k8sVersion  = ""
+	remote      = false
+	schemaFiles []string
+	crdFiles    []string
+	apiVersion  = ""
+	output      = ""
+	width       = 80
+	recursive   = false
+	// how fields marked deprecated or alpha are shown
+	unstableFields = view.ShowUnstable.String()
+	// k8s version of the doc in the other pane
+	otherK8sVersion = ""
 )
 
 type KexplainOptions struct {
 	// k8s
 	k8sConfigFlags *genericclioptions.ConfigFlags
 	mapper         mapper.Mapper
-	schema         openapi.Resources
+	schema         *model.Resources
 	version        string
 
 	args []string
@@ -78,10 +121,13 @@ func NewCmdKexplain(streams genericclioptions.IOStreams) *cobra.Command {
 		Example:      fmt.Sprintf(cliExample, cmdName),
 		SilenceUsage: true,
 		Version:      version.FullVersion(),
+		// resources are not subcommands, they're checked in Validate
+		Args: cobra.ArbitraryArgs,
+		// files are read before Complete in subcommands like object
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			return checkStdin(c.Flags())
+		},
 		RunE: func(c *cobra.Command, args []string) error {
-			if len(args) == 0 {
-				return c.Help()
-			}
 			if err := o.Complete(c, args); err != nil {
 				return err
 			}
@@ -97,11 +143,28 @@ func NewCmdKexplain(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	cmd.SetVersionTemplate(fmt.Sprintf(versionTemplate, strings.Replace(cmdName, " ", "-", 1)))
-	o.k8sConfigFlags.AddFlags(cmd.InheritedFlags())
-	cmd.Flags().BoolVar(&debug, "debug", false, "output debug log")
-	cmd.Flags().BoolVar(&remote, "remote", false, "force to use remote doc instead of k8s server")
-	cmd.Flags().StringVar(&k8sVersion, "k8s-version", "", "custom k8s version for fetching remote doc. Use latest by default")
-
+	// flags of getting doc are shared with subcommands
+	o.k8sConfigFlags.AddFlags(cmd.PersistentFlags())
+	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "output debug log")
+	cmd.PersistentFlags().BoolVar(&remote, "remote", false, "force to use remote doc instead of k8s server")
+	cmd.PersistentFlags().StringVar(&k8sVersion, "k8s-version", "", "custom k8s version for fetching remote doc. Use latest by default")
+	cmd.PersistentFlags().StringSliceVar(&schemaFiles, "schema-file", nil, "local swagger or OpenAPI v3 files to get doc from, \"-\" means stdin. "+
+		"Use multiple OpenAPI v3 files like ones of every group version")
+	cmd.PersistentFlags().StringVar(&apiVersion, "api-version", "", "get doc for a particular API version like apps/v1, the resource arg is \"resource.fields\" in this case")
+	cmd.PersistentFlags().StringSliceVar(&crdFiles, "crd-file", nil, "CustomResourceDefinition files or directories to get doc from, \"-\" means stdin")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format, one of text, json and yaml, which prints the doc instead of the interactive view. "+
+		"text is used by default when stdout is not a terminal")
+	cmd.Flags().IntVar(&width, "width", width, "width to wrap text in text output, 0 means no wrapping")
+	cmd.Flags().StringVar(&otherK8sVersion, "other-k8s-version", "", "open the resource of another k8s version from GitHub in the other pane, like \"1.27\"")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "show all fields recursively as a tree without descriptions. Press \"r\" to toggle it in the interactive view")
+	cmd.Flags().StringVar(&unstableFields, "unstable-fields", unstableFields, fmt.Sprintf("how to show fields marked deprecated or alpha, one of %s. "+
+		"Press \"F\" to switch it in the interactive view", strings.Join(view.StabilityFilterNames(), ", ")))
+
+	cmd.AddCommand(newCmdTemplate(o, cmdName))
+	cmd.AddCommand(newCmdObject(o, cmdName))
+	cmd.AddCommand(newCmdValidate(o, cmdName))
+	cmd.AddCommand(newCmdDiff(o, cmdName))
+	cmd.AddCommand(newCmdSearch(o, cmdName))
 	return cmd
 }
 
@@ -121,15 +184,36 @@ func newKexplainOptions(streams genericclioptions.IOStreams) *KexplainOptions {
 func (o *KexplainOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.args = args
 
-	var schema openapi.Resources
+	var schema *model.Resources
 	var mapper mapper.Mapper
 	var k8sErr error
-	if remote {
+	if len(schemaFiles) > 0 && len(crdFiles) > 0 {
+		return fmt.Errorf("--schema-file and --crd-file can't be used together")
+	}
+	if len(crdFiles) > 0 {
+		if debug {
+			log.Printf("get doc from CRD files %v\n", crdFiles)
+		}
+		var err error
+		schema, mapper, err = o.getFromCRDFiles(crdFiles)
+		if err != nil {
+			return err
+		}
+	} else if len(schemaFiles) > 0 {
+		if debug {
+			log.Printf("get doc from schema files %v\n", schemaFiles)
+		}
+		var err error
+		schema, mapper, err = o.getFromFiles(schemaFiles)
+		if err != nil {
+			return err
+		}
+	} else if remote {
 		if debug {
 			log.Println("get doc from remote directly")
 		}
 		var err error
-		schema, mapper, err = getFromRemote()
+		schema, mapper, err = getFromRemote(k8sVersion)
 		if debug {
 			if err == nil {
 				log.Println("done get schema from remote")
@@ -150,7 +234,7 @@ func (o *KexplainOptions) Complete(cmd *cobra.Command, args []string) error {
 			if debug {
 				log.Printf("fail to get k8s resources and get from remote: %s\n", k8sErr)
 			}
-			schema, mapper, err = getFromRemote()
+			schema, mapper, err = getFromRemote(k8sVersion)
 			if debug {
 				if err == nil {
 					log.Println("done get schema from remote")
@@ -171,39 +255,149 @@ func (o *KexplainOptions) Complete(cmd *cobra.Command, args []string) error {
 }
 
 func (o *KexplainOptions) Validate() error {
-	if len(o.args) == 0 {
-		return fmt.Errorf("resource is needed like CMD pod.spec, you can use `kubectl api-resources` to get resources list")
+	if len(o.args) > 2 {
+		return fmt.Errorf("at most two arguments are allowed")
 	}
-	if len(o.args) > 1 {
-		return fmt.Errorf("either one or no arguments are allowed")
+	if (len(o.args) > 1 || otherK8sVersion != "") && output != "" {
+		return fmt.Errorf("two docs can only be shown in the interactive view")
+	}
+	if len(o.args) > 1 && otherK8sVersion != "" {
+		return fmt.Errorf("--other-k8s-version can't be used with two arguments")
+	}
+	switch output {
+	case "", outputText, outputJSON, outputYAML:
+	default:
+		return fmt.Errorf("unsupported output format %q, use one of %q, %q and %q", output, outputText, outputJSON, outputYAML)
+	}
+	if (output == outputJSON || output == outputYAML) && len(o.args) == 0 {
+		return fmt.Errorf("a resource is required for -o %s", output)
+	}
+	if width < 0 {
+		return fmt.Errorf("--width can't be negative")
+	}
+	if _, ok := view.ParseStabilityFilter(unstableFields); !ok {
+		return fmt.Errorf("unsupported --unstable-fields %q, use one of %s", unstableFields, strings.Join(view.StabilityFilterNames(), ", "))
 	}
 	return nil
 }
 
 func (o *KexplainOptions) Run() error {
-	resource, fieldsPath := splitDotNotation(o.args[0])
-	gvk, err := o.mapper.KindFor(resource)
+	v := o.version
+	if v == "" {
+		v = k8sVersion
+	}
+	if output == "" && !isTerminal(o.Out) {
+		if len(o.args) > 1 || otherK8sVersion != "" {
+			return fmt.Errorf("two docs can only be shown in the interactive view")
+		}
+		output = outputText
+	}
+	if len(o.args) == 0 {
+		if output == outputText {
+			return view.PrintBrowserItems(o.Out, o.browserItems())
+		}
+		if err := browse(o.browserItems(), &docFinder{resources: o.schema, mapper: o.mapper}, v); err != nil {
+			fmt.Printf("failed to render: %s", err)
+		}
+		return nil
+	}
+
+	doc, err := o.findDoc(o.args[0])
 	if err != nil {
 		return err
 	}
 
-	found := o.schema.LookupResource(gvk)
-	if found == nil {
-		return fmt.Errorf("couldn't find resource for %q", gvk)
+	switch output {
+	case outputText:
+		return view.PrintDoc(o.Out, doc, width, recursive, stabilityFilter())
+	case outputJSON, outputYAML:
+		return printDocOutput(o.Out, doc, output)
 	}
 
-	v := o.version
-	if v == "" {
-		v = k8sVersion
+	// the other doc is shown in the split view
+	var otherDoc *model.Doc
+	otherVersion := v
+	finders := map[string]view.DocFinder{v: &docFinder{resources: o.schema, mapper: o.mapper}}
+	if len(o.args) > 1 {
+		if otherDoc, err = o.findDoc(o.args[1]); err != nil {
+			return err
+		}
+	} else if otherK8sVersion != "" {
+		otherSchema, otherMapper, err := getFromRemote(otherK8sVersion)
+		if err != nil {
+			return fmt.Errorf("fail to get schema of %s: %w", otherK8sVersion, err)
+		}
+		if otherDoc, err = findDocIn(otherSchema, otherMapper, o.args[0], apiVersion); err != nil {
+			return fmt.Errorf("%w in %s", err, otherK8sVersion)
+		}
+		otherVersion = otherK8sVersion
+		finders[otherVersion] = &docFinder{resources: otherSchema, mapper: otherMapper}
 	}
-	err = render(fieldsPath, found, gvk, v)
+
+	err = render(doc, v, otherDoc, otherVersion, finders)
 	if err != nil {
 		fmt.Printf("failed to render: %s", err)
 	}
 	return nil
 }
 
-func (o *KexplainOptions) getK8sResources() (openapi.Resources, mapper.Mapper, error) {
+// findDoc returns the doc of arg like `deploy.spec` in the command line, which uses --api-version
+func (o *KexplainOptions) findDoc(arg string) (*model.Doc, error) {
+	return findDocIn(o.schema, o.mapper, arg, apiVersion)
+}
+
+// docFinder finds docs of `:goto`, fields of `:search` and fields using types in the interactive view
+type docFinder struct {
+	resources *model.Resources
+	mapper    mapper.Mapper
+	// built for the first search
+	index *model.SearchIndex
+	// built for the first time of finding fields using a type
+	usedByIndex *model.UsedByIndex
+}
+
+// FindDoc returns the doc of `:goto`, whose version is in the path like `ingresses.v1beta1.extensions` instead of --api-version
+func (f *docFinder) FindDoc(path string) (*model.Doc, error) {
+	return findDocIn(f.resources, f.mapper, path, "")
+}
+
+func (f *docFinder) ResourceNames() []string {
+	return mapper.ResourceNames(f.mapper)
+}
+
+func (f *docFinder) Search(query string) []*model.SearchResult {
+	if f.index == nil {
+		f.index = model.NewResourcesSearchIndex(f.resources)
+	}
+	return f.index.Search(query)
+}
+
+func (f *docFinder) UsedBy(doc *model.Doc) ([]*model.UsedByResult, int) {
+	if f.usedByIndex == nil {
+		f.usedByIndex = model.NewResourcesUsedByIndex(f.resources)
+	}
+	return f.usedByIndex.UsedBy(doc)
+}
+
+// findDocIn returns the doc of arg in the schema, apiVersion is like `apps/v1` or empty
+func findDocIn(resources *model.Resources, m mapper.Mapper, arg, apiVersion string) (*model.Doc, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvk, fieldsPath, err := mapper.KindForArg(m, arg, gv)
+	if err != nil {
+		return nil, err
+	}
+
+	found := resources.LookupResource(gvk)
+	if found == nil {
+		return nil, fmt.Errorf("couldn't find resource for %q", gvk)
+	}
+	return model.NewDoc(found, fieldsPath, gvk)
+}
+
+func (o *KexplainOptions) getK8sResources() (*model.Resources, mapper.Mapper, error) {
 	if o.k8sConfigFlags.Timeout != nil && *o.k8sConfigFlags.Timeout == "" {
 		timeout := defaultKubeTimeout
 		o.k8sConfigFlags.Timeout = &timeout
@@ -215,11 +409,17 @@ func (o *KexplainOptions) getK8sResources() (openapi.Resources, mapper.Mapper, e
 	if v, err := discovery.ServerVersion(); err == nil {
 		o.version = v.String()
 	}
-	schema, err := discovery.OpenAPISchema()
+	schema, err := getK8sV3Schema(discovery)
 	if err != nil {
-		return nil, nil, fmt.Errorf("fail to get schema: %w", err)
+		if debug {
+			log.Printf("fail to get OpenAPI v3 schema, fall back to v2: %s\n", err)
+		}
+		schema, err = discovery.OpenAPISchema()
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to get schema: %w", err)
+		}
 	}
-	resources, err := openapi.NewOpenAPIData(schema)
+	resources, err := model.NewResources(schema)
 	if err != nil {
 		return nil, nil, fmt.Errorf("fail to get resources from schema: %w", err)
 	}
@@ -227,35 +427,108 @@ func (o *KexplainOptions) getK8sResources() (openapi.Resources, mapper.Mapper, e
 	if err != nil {
 		return nil, nil, fmt.Errorf("fail to get rest mapper: %w", err)
 	}
-	return resources, mapper.NewK8sMapper(k8sMapper), nil
+	return resources, mapper.NewK8sMapper(k8sMapper, discovery), nil
 }
 
-func splitDotNotation(model string) (string, []string) {
-	var fieldsPath []string
+// browserItems returns resources of the mapper which are in the schema
+func (o *KexplainOptions) browserItems() []view.BrowserItem {
+	resources := append([]mapper.Resource{}, o.mapper.Resources()...)
+	if len(resources) == 0 {
+		resources = mapper.GuessResources(o.schema.ListResources())
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Group != resources[j].Group {
+			return resources[i].Group < resources[j].Group
+		}
+		return resources[i].Plural < resources[j].Plural
+	})
+	items := make([]view.BrowserItem, 0, len(resources))
+	for _, r := range mapper.TopLevelResources(resources) {
+		if o.schema.LookupResource(r.GroupVersionKind) == nil {
+			continue
+		}
+		items = append(items, view.BrowserItem{
+			GVK:        r.GroupVersionKind,
+			Plural:     r.Plural,
+			ShortNames: r.ShortNames,
+			Namespaced: r.Namespaced,
+		})
+	}
+	return items
+}
 
-	// ignore trailing period
-	model = strings.TrimSuffix(model, ".")
+// stabilityFilter returns the filter of --unstable-fields, which is checked in Validate
+func stabilityFilter() view.StabilityFilter {
+	filter, _ := view.ParseStabilityFilter(unstableFields)
+	return filter
+}
 
-	dotModel := strings.Split(model, ".")
-	if len(dotModel) >= 1 {
-		fieldsPath = dotModel[1:]
+// render shows the doc, and the other doc side by side if it's not nil.
+// finders are finders of `:goto` by versions.
+func render(doc *model.Doc, version string, other *model.Doc, otherVersion string, finders map[string]view.DocFinder) error {
+	app := tview.NewApplication()
+	splitView := view.NewSplitView(doc)
+	splitView.SetStopFn(func() { app.Stop() })
+	for v, finder := range finders {
+		splitView.SetDocFinder(v, finder)
+	}
+	splitView.SetVersion(version)
+	splitView.SetRecursive(recursive)
+	splitView.SetStabilityFilter(stabilityFilter())
+	if other != nil {
+		splitView.Split(other, otherVersion)
 	}
-	return dotModel[0], fieldsPath
+	return app.SetRoot(splitView, true).Run()
 }
 
-func render(fieldsPath []string, schema proto.Schema, gvk schema.GroupVersionKind, version string) error {
-	doc, err := model.NewDoc(schema, fieldsPath, gvk)
+// browse shows the resource list, and the doc of a resource after selecting it
+func browse(items []view.BrowserItem, finder *docFinder, version string) error {
+	app := tview.NewApplication()
+	browser := view.NewBrowser(items)
+	browser.SetStopFn(func() { app.Stop() })
+	browser.SetSelectFn(func(item view.BrowserItem) {
+		doc, err := model.NewDoc(finder.resources.LookupResource(item.GVK), nil, item.GVK)
+		if err != nil {
+			return
+		}
+		page := view.NewPage(doc)
+		page.SetStopFn(func() { app.Stop() })
+		page.SetListFn(func() { app.SetRoot(browser, true) })
+		page.SetVersion(version)
+		page.SetRecursive(recursive)
+		page.SetStabilityFilter(stabilityFilter())
+		page.SetDocFinder(finder)
+		app.SetRoot(view.NewNavigator(page), true)
+	})
+	return app.SetRoot(browser, true).Run()
+}
+
+// isTerminal returns true if w is a terminal, otherwise the interactive view can't be used
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
 	if err != nil {
-		return err
+		return false
 	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
 
-	app := tview.NewApplication()
-	page := view.NewPage(doc)
-	page.SetStopFn(func() { app.Stop() })
-	page.SetVersion(version)
-	if err := app.SetRoot(page, true).Run(); err != nil {
-		return err
+// printDocOutput prints the structured doc as JSON or YAML, see model.DocOutput
+func printDocOutput(w io.Writer, doc *model.Doc, format string) error {
+	var data []byte
+	var err error
+	if format == outputJSON {
+		data, err = json.MarshalIndent(doc.Output(), "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(doc.Output())
 	}
-
-	return nil
+	if err != nil {
+		return fmt.Errorf("fail to marshal doc: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
 }
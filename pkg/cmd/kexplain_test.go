@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"kexplain/pkg/testutil"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestFindDocIn(t *testing.T) {
+	o := &KexplainOptions{}
+	resources, m, err := o.getFromFiles([]string{testutil.Path("kinds.yaml")})
+	if err != nil {
+		t.Fatalf("getFromFiles() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		arg        string
+		apiVersion string
+		want       schema.GroupVersionKind
+	}{
+		{name: "preferred", arg: "ingresses", want: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}},
+		{name: "version in path", arg: "ingresses.v1beta1.extensions", want: schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}},
+		{name: "api version", arg: "ingresses", apiVersion: "extensions/v1beta1", want: schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := findDocIn(resources, m, tt.arg, tt.apiVersion)
+			if err != nil {
+				t.Fatalf("findDocIn() error = %v", err)
+			}
+			if got := doc.GetGroupVersionKind(); got != tt.want {
+				t.Errorf("findDocIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+var manifestFileExts = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// stdinFlags are flags of files where "-" means stdin
+var stdinFlags = []string{"schema-file", "crd-file", "filename"}
+
+// checkStdin returns an error if stdin is used more than once in flags of files like `-f - --schema-file -`,
+// because it can only be read once
+func checkStdin(flags *pflag.FlagSet) error {
+	used := []string{}
+	for _, name := range stdinFlags {
+		f := flags.Lookup(name)
+		if f == nil {
+			continue
+		}
+		values := []string{f.Value.String()}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			values = slice.GetSlice()
+		}
+		for _, v := range values {
+			if v == "-" {
+				used = append(used, "--"+name)
+			}
+		}
+	}
+	if len(used) > 1 {
+		return fmt.Errorf("stdin \"-\" can only be read once, but it's used by %s", strings.Join(used, ", "))
+	}
+	return nil
+}
+
+// walkManifests calls fn with every manifest file in paths, which are files or directories,
+// or stdin when path is "-"
+func (o *KexplainOptions) walkManifests(paths []string, fn func(r io.Reader, name string) error) error {
+	for _, path := range paths {
+		if path == "-" {
+			if err := fn(o.In, "stdin"); err != nil {
+				return err
+			}
+			continue
+		}
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// files in directories are filtered by extensions, but files in args are always read
+			if d.IsDir() || (p != path && !manifestFileExts[filepath.Ext(p)]) {
+				return nil
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return fn(f, p)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
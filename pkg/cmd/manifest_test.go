@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestCheckStdin(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "once", args: []string{"validate", "-f", "-", "--schema-file", "swagger.json"}},
+		{name: "schema file and filename", args: []string{"validate", "-f", "-", "--schema-file", "-"},
+			wantErr: "used by --schema-file, --filename"},
+		{name: "repeated filename", args: []string{"validate", "-f", "-", "-f", "-"}, wantErr: "used by --filename, --filename"},
+		{name: "crd file and object", args: []string{"object", "-f", "-", "--crd-file", "-"}, wantErr: "used by --crd-file, --filename"},
+		{name: "schema files", args: []string{"deploy", "--schema-file", "-,-"}, wantErr: "used by --schema-file, --schema-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// flags are package variables, which are reset to defaults by a new command
+			root := NewCmdKexplain(genericclioptions.IOStreams{})
+			c, args, err := root.Find(tt.args)
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+			if err := c.ParseFlags(args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+			err = checkStdin(c.Flags())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkStdin() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkStdin() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kexplain/pkg/model"
+	"kexplain/pkg/view"
+	"os"
+
+	"github.com/rivo/tview"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const objectExample = `
+	# Explain fields of a live object
+	%[1]s object deploy/nginx -n default
+
+	# Explain fields of an object in a manifest, or "-" for stdin
+	%[1]s object -f deploy.yaml
+	kubectl get deploy nginx -o yaml | %[1]s object -f -
+`
+
+var (
+	objectFile      = ""
+	objectNamespace = ""
+)
+
+func newCmdObject(o *KexplainOptions, cmdName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "object (<type>[.<version>][.<group>]/<name> | -f <filename>)",
+		Short: "Explain fields of a live object or a manifest",
+		Long: `Show an object in YAML, and the documentation of the field under the cursor.
+
+Press Enter to get the full documentation of the field, and Tab to scroll the documentation.`,
+		Example:      fmt.Sprintf(objectExample, cmdName),
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if (len(args) == 0) == (objectFile == "") {
+				return fmt.Errorf("either a <type>/<name> argument or -f is required")
+			}
+			var obj map[string]interface{}
+			var err error
+			if objectFile != "" {
+				obj, err = o.readObject(objectFile)
+			} else {
+				obj, err = o.getObject(args[0], objectNamespace)
+			}
+			if err != nil {
+				return err
+			}
+			if err := o.Complete(c, nil); err != nil {
+				return err
+			}
+
+			u := &unstructured.Unstructured{Object: obj}
+			// they're hidden in `kubectl get -o yaml` too
+			unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+			gvk := u.GroupVersionKind()
+			found := o.schema.LookupResource(gvk)
+			if found == nil {
+				return fmt.Errorf("couldn't find resource for %q", gvk)
+			}
+			objectView := view.NewObjectView(u.Object, found, gvk)
+			v := o.version
+			if v == "" {
+				v = k8sVersion
+			}
+			if err := renderObject(objectView, v); err != nil {
+				fmt.Printf("failed to render: %s", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&objectFile, "filename", "f", "", "manifest file of the object, \"-\" means stdin")
+	cmd.Flags().StringVarP(&objectNamespace, "namespace", "n", "", "namespace of the object, the one of the current context by default")
+	return cmd
+}
+
+// readObject reads the only object in a YAML or JSON manifest
+func (o *KexplainOptions) readObject(path string) (map[string]interface{}, error) {
+	r := o.In
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read manifest: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	objs := []map[string]interface{}{}
+	for {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fail to read manifest: %w", err)
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		jsonData, err := yaml.ToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("fail to parse manifest: %w", err)
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(jsonData, &obj); err != nil {
+			return nil, fmt.Errorf("fail to parse manifest: %w", err)
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	if len(objs) != 1 {
+		return nil, fmt.Errorf("one object is expected in the manifest, but got %d", len(objs))
+	}
+	return objs[0], nil
+}
+
+// getObject gets an object like `deploy/nginx` from k8s server
+func (o *KexplainOptions) getObject(arg, namespace string) (map[string]interface{}, error) {
+	if o.k8sConfigFlags.Timeout != nil && *o.k8sConfigFlags.Timeout == "" {
+		timeout := defaultKubeTimeout
+		o.k8sConfigFlags.Timeout = &timeout
+	}
+	if namespace == "" {
+		var err error
+		namespace, _, err = o.k8sConfigFlags.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("fail to get namespace: %w", err)
+		}
+	}
+	infos, err := resource.NewBuilder(o.k8sConfigFlags).
+		Unstructured().
+		NamespaceParam(namespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(false, arg).
+		SingleResourceType().
+		Flatten().
+		Do().
+		Infos()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get %s: %w", arg, err)
+	}
+	if len(infos) != 1 {
+		return nil, fmt.Errorf("one object is expected for %s, but got %d", arg, len(infos))
+	}
+	u, ok := infos[0].Object.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("fail to get %s: unexpected object %T", arg, infos[0].Object)
+	}
+	return u.Object, nil
+}
+
+// renderObject shows the object, and the doc of a field after selecting it
+func renderObject(objectView *view.ObjectView, version string) error {
+	app := tview.NewApplication()
+	objectView.SetStopFn(func() { app.Stop() })
+	objectView.SetSelectFn(func(doc *model.Doc) {
+		page := view.NewPage(doc)
+		page.SetStopFn(func() { app.Stop() })
+		page.SetListFn(func() { app.SetRoot(objectView, true) })
+		page.SetVersion(version)
+		app.SetRoot(page, true)
+	})
+	return app.SetRoot(objectView, true).Run()
+}
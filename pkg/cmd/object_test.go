@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"kexplain/pkg/testutil"
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestReadObject(t *testing.T) {
+	o := &KexplainOptions{}
+	obj, err := o.readObject(testutil.Path("deployment-object.yaml"))
+	if err != nil {
+		t.Fatalf("readObject() error = %v", err)
+	}
+	if obj["kind"] != "Deployment" {
+		t.Errorf("kind = %v, want Deployment", obj["kind"])
+	}
+}
+
+func TestReadObjectNotOne(t *testing.T) {
+	tests := map[string]string{
+		"empty": "---\n",
+		"two":   "kind: Pod\n---\nkind: Service\n",
+	}
+	for name, in := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &KexplainOptions{IOStreams: genericclioptions.IOStreams{In: strings.NewReader(in)}}
+			if _, err := o.readObject("-"); err == nil {
+				t.Error("readObject() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kexplain/pkg/model"
+	"log"
+	"sort"
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"k8s.io/client-go/discovery"
+)
+
+const openAPIV3Path = "/openapi/v3"
+
+// getK8sV3Schema fetches OpenAPI v3 documents of all group versions from k8s server
+// and converts them to one v2 document.
+func getK8sV3Schema(client discovery.DiscoveryInterface) (*openapi_v2.Document, error) {
+	restClient := client.RESTClient()
+	if restClient == nil {
+		return nil, fmt.Errorf("no rest client for OpenAPI v3")
+	}
+	data, err := restClient.Get().AbsPath(openAPIV3Path).Do(context.TODO()).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var root struct {
+		Paths map[string]struct {
+			ServerRelativeURL string `json:"serverRelativeURL"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(root.Paths))
+	for name := range root.Paths {
+		// only group versions like api/v1 and apis/apps/v1 have kinds
+		if name == "api/v1" || (strings.HasPrefix(name, "apis/") && strings.Count(name, "/") == 2) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no group versions in OpenAPI v3")
+	}
+	sort.Strings(names)
+
+	specs := make([][]byte, 0, len(names))
+	for _, name := range names {
+		uri := root.Paths[name].ServerRelativeURL
+		if uri == "" {
+			uri = openAPIV3Path + "/" + name
+		}
+		if debug {
+			log.Printf("fetching OpenAPI v3 of %s\n", name)
+		}
+		spec, err := restClient.Get().RequestURI(uri).Do(context.TODO()).Raw()
+		if err != nil {
+			return nil, fmt.Errorf("fail to get OpenAPI v3 of %s: %w", name, err)
+		}
+		specs = append(specs, spec)
+	}
+	return model.NewDocumentFromV3(specs...)
+}
@@ -6,15 +6,17 @@ import (
 	"fmt"
 	"io"
 	"kexplain/pkg/mapper"
+	"kexplain/pkg/model"
 	"log"
 	"net/http"
 	"os"
 	"path"
+	"regexp"
+	"strings"
 	"time"
 
 	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
 	"github.com/mitchellh/go-homedir"
-	"k8s.io/kubectl/pkg/util/openapi"
 )
 
 const (
@@ -25,8 +27,9 @@ const (
 	cacheTime                   = time.Hour * 24 * 7
 )
 
-func getFromRemote() (openapi.Resources, mapper.Mapper, error) {
-	data, err := cacheOrFetch()
+// getFromRemote loads the schema of a k8s version like `1.24` or `v1.24.3` from GitHub, the latest for an empty version
+func getFromRemote(version string) (*model.Resources, mapper.Mapper, error) {
+	data, err := cacheOrFetch(version)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -35,81 +38,70 @@ func getFromRemote() (openapi.Resources, mapper.Mapper, error) {
 		return nil, nil, err
 	}
 
-	schema, err := openapi.NewOpenAPIData(doc)
+	schema, err := model.NewResources(doc)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return schema, mapper.NewRawMapper(), nil
+	return schema, mapper.NewRawMapper(mapper.ResourcesFromDocument(doc, schema.ListResources())), nil
 }
 
-func cacheOrFetch() ([]byte, error) {
+// cacheOrFetch returns the swagger of the version from the local cache, or fetches it if the cache expires
+func cacheOrFetch(version string) ([]byte, error) {
 	cacheDir, err := homedir.Expand(defaultCacheDir)
 	if err != nil {
-		return fetchFromRemote()
+		return fetchFromRemote(version)
 	}
 	err = os.MkdirAll(cacheDir, 0755)
 	if err != nil {
-		return fetchFromRemote()
+		return fetchFromRemote(version)
 	}
 
-	filename := cacheName()
-	p := path.Join(cacheDir, filename)
-	file, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE, 0664)
-	if err != nil {
-		return fetchFromRemote()
-	}
-
-	defer file.Close()
-
-	fetchFromRemoteAndSaveCache := func() ([]byte, error) {
-		d, err := fetchFromRemote()
-		if err != nil {
-			return nil, err
+	p := path.Join(cacheDir, cacheName(version))
+	// use the cache if it's readable, not empty and Now - ModTime <= cacheTime
+	if stat, err := os.Stat(p); err == nil && time.Since(stat.ModTime()) <= cacheTime {
+		if d, err := os.ReadFile(p); err == nil && len(d) > 0 {
+			if debug {
+				log.Println("use local cache as remote data")
+			}
+			return d, nil
 		}
-		if debug {
-			log.Println("write to local cache using remote data")
-		}
-		_, err = file.Write(d)
-		if err != nil {
-			return nil, err
-		}
-		return d, nil
-	}
-	stat, err := file.Stat()
-	// can't get mod time or Now - ModTime > cacheTime
-	if err != nil || time.Since(stat.ModTime()) > cacheTime {
-		return fetchFromRemoteAndSaveCache()
 	}
 
-	d, err := io.ReadAll(file)
+	d, err := fetchFromRemote(version)
 	if err != nil {
-		return fetchFromRemoteAndSaveCache()
-	}
-	if len(d) == 0 {
-		return fetchFromRemoteAndSaveCache()
+		return nil, err
 	}
 	if debug {
-		log.Println("use local cache as remote data")
+		log.Println("write to local cache using remote data")
+	}
+	// the expired cache is truncated, it may be longer than the new one
+	if err := os.WriteFile(p, d, 0664); err != nil {
+		return nil, err
 	}
 	return d, nil
 }
 
-func cacheName() string {
-	sum := md5.Sum([]byte(remoteUrl()))
+func cacheName(version string) string {
+	sum := md5.Sum([]byte(remoteUrl(version)))
 	return cacheFilePrefix + hex.EncodeToString(sum[:])
 }
 
-func fetchFromRemote() ([]byte, error) {
+func fetchFromRemote(version string) ([]byte, error) {
 	if debug {
 		log.Println("fetching doc from remote")
 	}
 	client := &http.Client{Timeout: defaultRemoteTimeoutSeconds * time.Second}
-	resp, err := client.Get(remoteUrl())
+	url := remoteUrl(version)
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	// error pages like `404: Not Found` must not be cached as the doc
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("fail to fetch %s: %s", url, resp.Status)
+	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -118,10 +110,23 @@ func fetchFromRemote() ([]byte, error) {
 	return data, nil
 }
 
-func remoteUrl() string {
-	if k8sVersion == "" {
-		return fmt.Sprintf(defaultRemoteURL, "master")
-	}
+func remoteUrl(version string) string {
+	return fmt.Sprintf(defaultRemoteURL, gitRef(version))
+}
 
-	return fmt.Sprintf(defaultRemoteURL, k8sVersion)
+var minorVersionRe = regexp.MustCompile(`^v?(\d+\.\d+)$`)
+var patchVersionRe = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+
+// gitRef returns the ref of kubernetes repo for a version,
+// which is a release branch like `release-1.24` for `1.24`, and a tag like `v1.24.3` for `1.24.3`.
+func gitRef(version string) string {
+	switch {
+	case version == "":
+		return "master"
+	case minorVersionRe.MatchString(version):
+		return "release-" + minorVersionRe.FindStringSubmatch(version)[1]
+	case patchVersionRe.MatchString(version):
+		return "v" + strings.TrimPrefix(version, "v")
+	}
+	return version
 }
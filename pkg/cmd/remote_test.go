@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestGitRef(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "", want: "master"},
+		{version: "1.24", want: "release-1.24"},
+		{version: "v1.24", want: "release-1.24"},
+		{version: "1.24.3", want: "v1.24.3"},
+		{version: "v1.27.0-alpha.1", want: "v1.27.0-alpha.1"},
+		{version: "my-branch", want: "my-branch"},
+	}
+	for _, tt := range tests {
+		if got := gitRef(tt.version); got != tt.want {
+			t.Errorf("gitRef(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"kexplain/pkg/model"
+	"kexplain/pkg/view"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const searchExample = `
+	# List fields of all resources whose names or descriptions contain the text
+	%[1]s search topologySpreadConstraints
+
+	# Search in a local swagger file
+	%[1]s search "DNS subdomain" --schema-file swagger.json
+`
+
+func newCmdSearch(o *KexplainOptions, cmdName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <text>",
+		Short: "Search names and descriptions of fields of all resources",
+		Long: `Search names and descriptions of fields of all resources ignoring case.
+
+Fields with the same name are listed first, then fields whose names contain the text, then ones whose descriptions contain it.
+Use ":search <text>" in the interactive view to go to the documentation of a result.`,
+		Example:      fmt.Sprintf(searchExample, cmdName),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, nil); err != nil {
+				return err
+			}
+			query := strings.Join(args, " ")
+			results := model.NewResourcesSearchIndex(o.schema).Search(query)
+			if len(results) == 0 {
+				return fmt.Errorf("%q is not found", query)
+			}
+			return view.PrintSearchResults(o.Out, results)
+		},
+	}
+	return cmd
+}
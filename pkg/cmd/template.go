@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"kexplain/pkg/model"
+
+	"github.com/spf13/cobra"
+)
+
+const templateExample = `
+	# Print a manifest skeleton of a resource
+	%[1]s template deploy
+
+	# Print a manifest skeleton of a field
+	%[1]s template deploy.spec.template --depth 3 > pod-template.yaml
+`
+
+var templateDepth = model.DefaultTemplateDepth
+
+func newCmdTemplate(o *KexplainOptions, cmdName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template <type>[.<version>][.<group>][.<fieldName>]",
+		Short: "Print a YAML manifest skeleton of a resource or field",
+		Long: `Print a YAML manifest skeleton of a resource or field.
+
+Required fields are filled with placeholders, and optional fields are commented out with their descriptions.`,
+		Example:      fmt.Sprintf(templateExample, cmdName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if templateDepth < 1 {
+				return fmt.Errorf("--depth must be positive")
+			}
+			doc, err := o.findDoc(args[0])
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(o.Out, doc.Template(templateDepth))
+			return err
+		},
+	}
+	cmd.Flags().IntVar(&templateDepth, "depth", templateDepth, "depth of objects to expand")
+	return cmd
+}
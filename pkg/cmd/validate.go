@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"kexplain/pkg/model"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const validateExample = `
+	# Validate manifests in files or directories against the schema of k8s server
+	%[1]s validate -f deploy.yaml -f manifests/
+
+	# Validate manifests without k8s server, like in a pre-commit hook
+	%[1]s validate -f manifests/ --schema-file swagger.json
+	%[1]s validate -f manifests/ --crd-file crds/ --skip-unknown-kinds
+`
+
+var (
+	validateFiles    []string
+	skipUnknownKinds = false
+)
+
+func newCmdValidate(o *KexplainOptions, cmdName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate -f <filename>",
+		Short: "Validate manifests against the schema",
+		Long: `Validate manifests against the schema, checking unknown fields, missing required fields and types.
+
+Scalars are typed by YAML 1.1 like the API server does, so "yes" and "on" are booleans.
+Items of "kind: List" like the output of "kubectl get -o yaml" are validated one by one.
+Problems are printed as "file:line: path: message" with the description of the field.
+It exits with 1 if any manifest is invalid or the schema can't be loaded.`,
+		Example:      fmt.Sprintf(validateExample, cmdName),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if len(validateFiles) == 0 {
+				return fmt.Errorf("-f is required")
+			}
+			if err := o.Complete(c, nil); err != nil {
+				return err
+			}
+			count := 0
+			err := o.walkManifests(validateFiles, func(r io.Reader, name string) error {
+				errs, err := o.validateManifest(r)
+				if err != nil {
+					return fmt.Errorf("fail to parse %s: %w", name, err)
+				}
+				for _, e := range errs {
+					fmt.Fprintf(o.Out, "%s:%s\n", name, e)
+					if e.Description != "" {
+						fmt.Fprintf(o.Out, "    %s\n", e.Description)
+					}
+				}
+				count += len(errs)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%d problem(s) found", count)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&validateFiles, "filename", "f", nil, "manifest files or directories to validate, \"-\" means stdin")
+	cmd.Flags().BoolVar(&skipUnknownKinds, "skip-unknown-kinds", false, "skip manifests whose kinds are not in the schema, like custom resources")
+	return cmd
+}
+
+// validateManifest validates every document in the YAML or JSON stream
+func (o *KexplainOptions) validateManifest(r io.Reader) ([]*model.ValidationError, error) {
+	decoder := yaml.NewDecoder(r)
+	errs := []*model.ValidationError{}
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		objErrs, err := o.validateObject(node.Content[0])
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, objErrs...)
+	}
+	return errs, nil
+}
+
+// validateObject validates the object in the mapping node, or every item of it if it's a List
+func (o *KexplainOptions) validateObject(node *yaml.Node) ([]*model.ValidationError, error) {
+	var head struct {
+		APIVersion string      `yaml:"apiVersion"`
+		Kind       string      `yaml:"kind"`
+		Items      []yaml.Node `yaml:"items"`
+	}
+	if err := node.Decode(&head); err != nil {
+		return nil, err
+	}
+	if head.APIVersion == "" || head.Kind == "" {
+		return []*model.ValidationError{{Line: node.Line, Path: "", Message: "apiVersion and kind are required"}}, nil
+	}
+	// `kubectl get -o yaml` prints objects as items of a List, which isn't in the schema
+	if head.Kind == "List" {
+		errs := []*model.ValidationError{}
+		for i := range head.Items {
+			item := &head.Items[i]
+			if item.Kind != yaml.MappingNode {
+				errs = append(errs, &model.ValidationError{Line: item.Line, Path: fmt.Sprintf("items[%d]", i), Message: "expected object"})
+				continue
+			}
+			itemErrs, err := o.validateObject(item)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range itemErrs {
+				e.Path = strings.TrimSuffix(fmt.Sprintf("items[%d].%s", i, e.Path), ".")
+			}
+			errs = append(errs, itemErrs...)
+		}
+		return errs, nil
+	}
+	gv, err := schema.ParseGroupVersion(head.APIVersion)
+	if err != nil {
+		return []*model.ValidationError{{Line: node.Line, Path: "apiVersion", Message: err.Error()}}, nil
+	}
+	gvk := gv.WithKind(head.Kind)
+	s := o.schema.LookupResource(gvk)
+	if s == nil {
+		if skipUnknownKinds {
+			return nil, nil
+		}
+		return []*model.ValidationError{{Line: node.Line, Path: "kind", Message: fmt.Sprintf("unknown kind %q of %q", head.Kind, head.APIVersion)}}, nil
+	}
+	return model.Validate(node, s), nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"kexplain/pkg/model"
+	"kexplain/pkg/testutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateManifest(t *testing.T) {
+	resources, err := model.NewResources(testutil.Document(t, "deployment.yaml"))
+	if err != nil {
+		t.Fatalf("fail to create resources: %v", err)
+	}
+	o := &KexplainOptions{schema: resources}
+	manifests := `apiVersion: apps/v1
+kind: Deployment
+spec:
+  replica: 1
+---
+apiVersion: v1
+kind: Pod
+---
+kind: Deployment
+`
+	tests := []struct {
+		skipUnknownKinds bool
+		want             []string
+	}{
+		{
+			want: []string{
+				"3: spec.selector: missing required field",
+				"3: spec.template: missing required field",
+				"4: spec.replica: unknown field, did you mean replicas?",
+				`6: kind: unknown kind "Pod" of "v1"`,
+				"9: apiVersion and kind are required",
+			},
+		},
+		{
+			skipUnknownKinds: true,
+			want: []string{
+				"3: spec.selector: missing required field",
+				"3: spec.template: missing required field",
+				"4: spec.replica: unknown field, did you mean replicas?",
+				"9: apiVersion and kind are required",
+			},
+		},
+	}
+	for _, tt := range tests {
+		skipUnknownKinds = tt.skipUnknownKinds
+		errs, err := o.validateManifest(strings.NewReader(manifests))
+		if err != nil {
+			t.Fatalf("validateManifest() error = %v", err)
+		}
+		got := []string{}
+		for _, e := range errs {
+			got = append(got, e.Error())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("validateManifest() with skipUnknownKinds %v = %q, want %q", tt.skipUnknownKinds, got, tt.want)
+		}
+	}
+	skipUnknownKinds = false
+}
+
+func TestValidateManifestList(t *testing.T) {
+	resources, err := model.NewResources(testutil.Document(t, "deployment.yaml"))
+	if err != nil {
+		t.Fatalf("fail to create resources: %v", err)
+	}
+	o := &KexplainOptions{schema: resources}
+	manifest := `apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  spec:
+    replicas: 1
+    selector: {}
+    template: {}
+- apiVersion: apps/v1
+  kind: Deployment
+  spec:
+    replica: 1
+- kind: Deployment
+- nginx
+`
+	want := []string{
+		"12: items[1].spec.selector: missing required field",
+		"12: items[1].spec.template: missing required field",
+		"13: items[1].spec.replica: unknown field, did you mean replicas?",
+		"14: items[2]: apiVersion and kind are required",
+		"15: items[3]: expected object",
+	}
+	errs, err := o.validateManifest(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatalf("validateManifest() error = %v", err)
+	}
+	got := []string{}
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateManifest() = %q, want %q", got, want)
+	}
+}
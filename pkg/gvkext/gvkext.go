@@ -0,0 +1,28 @@
+// Package gvkext parses the group version kind extension of schemas, which is used by both model and mapper
+package gvkext
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// Parse returns kinds in the value of `x-kubernetes-group-version-kind`,
+// which is a list in definitions and a single one in operations of paths
+func Parse(ext interface{}) []schema.GroupVersionKind {
+	list, ok := ext.([]interface{})
+	if !ok {
+		list = []interface{}{ext}
+	}
+	result := make([]schema.GroupVersionKind, 0, len(list))
+	for _, item := range list {
+		gvkMap, ok := item.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		group, _ := gvkMap["group"].(string)
+		version, _ := gvkMap["version"].(string)
+		kind, _ := gvkMap["kind"].(string)
+		if version == "" || kind == "" {
+			continue
+		}
+		result = append(result, schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+	}
+	return result
+}
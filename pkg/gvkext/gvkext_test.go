@@ -0,0 +1,46 @@
+package gvkext
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParse(t *testing.T) {
+	deploy := map[interface{}]interface{}{"group": "apps", "version": "v1", "kind": "Deployment"}
+	pod := map[interface{}]interface{}{"group": "", "version": "v1", "kind": "Pod"}
+	tests := []struct {
+		name string
+		ext  interface{}
+		want []schema.GroupVersionKind
+	}{
+		{
+			name: "list in definitions",
+			ext:  []interface{}{deploy, pod},
+			want: []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}, {Version: "v1", Kind: "Pod"}},
+		},
+		{
+			name: "single one in operations",
+			ext:  deploy,
+			want: []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}},
+		},
+		{
+			name: "without kind",
+			ext:  []interface{}{map[interface{}]interface{}{"group": "apps", "version": "v1"}},
+			want: []schema.GroupVersionKind{},
+		},
+		{
+			name: "absent",
+			ext:  nil,
+			want: []schema.GroupVersionKind{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.ext); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
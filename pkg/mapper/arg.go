@@ -0,0 +1,90 @@
+package mapper
+
+import (
+	"fmt"
+	"kexplain/pkg/suggest"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KindForArg returns the kind and fields path of an arg like `deploy.spec`, `deployments.apps.spec`
+// or `ingresses.v1.networking.k8s.io.spec`. Group and version are taken from gv when it's not empty,
+// and the arg is `resource.fields` like `kubectl explain` in this case.
+func KindForArg(m Mapper, arg string, gv schema.GroupVersion) (schema.GroupVersionKind, []string, error) {
+	// ignore trailing period
+	segments := strings.Split(strings.TrimSuffix(arg, "."), ".")
+
+	if gv.Empty() {
+		// try the longest `resource.version.group` or `resource.group` first, the rest is fields path
+		for i := len(segments); i >= 2; i-- {
+			gvr, gr := schema.ParseResourceArg(strings.Join(segments[:i], "."))
+			candidates := []schema.GroupVersionResource{gr.WithVersion("")}
+			if gvr != nil {
+				candidates = append([]schema.GroupVersionResource{*gvr}, candidates...)
+			}
+			for _, c := range candidates {
+				gvk, err := m.KindFor(c)
+				if err == nil {
+					return gvk, segments[i:], nil
+				}
+				if meta.IsAmbiguousError(err) {
+					return gvk, nil, ambiguityError(err)
+				}
+			}
+		}
+	}
+
+	gvk, err := m.KindFor(gv.WithResource(segments[0]))
+	if meta.IsAmbiguousError(err) {
+		err = ambiguityError(err)
+	} else if err != nil {
+		err = withResourceSuggestions(err, m, segments)
+	}
+	return gvk, segments[1:], err
+}
+
+// withResourceSuggestions adds resources close to the first segment of the arg to the error
+func withResourceSuggestions(err error, m Mapper, segments []string) error {
+	suggestions := suggest.Closest(segments[0], ResourceNames(m))
+	if len(suggestions) == 0 {
+		return err
+	}
+	for i := range suggestions {
+		suggestions[i] = strings.Join(append([]string{suggestions[i]}, segments[1:]...), ".")
+	}
+	return fmt.Errorf("%w, did you mean %s?", err, strings.Join(suggestions, " or "))
+}
+
+// ResourceNames returns sorted names of resources which can be used in args,
+// like plural and singular names, lower case kinds and short names. See TopLevelResources.
+func ResourceNames(m Mapper) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, r := range TopLevelResources(m.Resources()) {
+		for _, name := range append([]string{r.Plural, r.Singular, strings.ToLower(r.Kind)}, r.ShortNames...) {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ambiguityError lists the candidates in kubectl style like `ingresses.v1.networking.k8s.io`
+func ambiguityError(err error) error {
+	ambiguous, ok := err.(*meta.AmbiguousResourceError)
+	if !ok || len(ambiguous.MatchingResources) == 0 {
+		return err
+	}
+	candidates := make([]string, 0, len(ambiguous.MatchingResources))
+	for _, r := range ambiguous.MatchingResources {
+		candidates = append(candidates, formatResource(r))
+	}
+	return fmt.Errorf("%q is ambiguous, use one of these or --api-version:\n  %s",
+		ambiguous.PartialResource.Resource, strings.Join(candidates, "\n  "))
+}
@@ -0,0 +1,135 @@
+package mapper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func testResources() []Resource {
+	namespaced := true
+	return []Resource{
+		{GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Plural: "deployments", Singular: "deployment", ShortNames: []string{"deploy"}, Namespaced: &namespaced},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DeploymentList"},
+			Plural: "deploymentlists", Singular: "deploymentlist"},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"},
+			Plural: "scales", Singular: "scale"},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+			Plural: "ingresses", Singular: "ingress", ShortNames: []string{"ing"}, Namespaced: &namespaced},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+			Plural: "ingresses", Singular: "ingress", ShortNames: []string{"ing"}, Namespaced: &namespaced},
+		{GroupVersionKind: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Ingress"},
+			Plural: "ingresses", Singular: "ingress", Namespaced: &namespaced},
+	}
+}
+
+func TestKindForArg(t *testing.T) {
+	m := NewRawMapper(testResources())
+	tests := []struct {
+		name       string
+		arg        string
+		gv         schema.GroupVersion
+		wantKind   schema.GroupVersionKind
+		wantFields []string
+		wantErr    string
+	}{
+		{
+			name:       "short name",
+			arg:        "deploy.spec.replicas",
+			wantKind:   schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			wantFields: []string{"spec", "replicas"},
+		},
+		{
+			name:       "trailing period",
+			arg:        "deployments.",
+			wantKind:   schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			wantFields: []string{},
+		},
+		{
+			name:       "resource.group",
+			arg:        "deployments.apps.spec",
+			wantKind:   schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			wantFields: []string{"spec"},
+		},
+		{
+			name:       "resource.version.group",
+			arg:        "ingresses.v1beta1.extensions.spec",
+			wantKind:   schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+			wantFields: []string{"spec"},
+		},
+		{
+			name:       "group with periods",
+			arg:        "ing.v1.networking.k8s.io.spec.rules",
+			wantKind:   schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+			wantFields: []string{"spec", "rules"},
+		},
+		{
+			name:       "api version",
+			arg:        "ingress.spec",
+			gv:         schema.GroupVersion{Group: "extensions", Version: "v1beta1"},
+			wantKind:   schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+			wantFields: []string{"spec"},
+		},
+		{
+			name:    "ambiguous",
+			arg:     "ingresses",
+			wantErr: `"ingresses" is ambiguous`,
+		},
+		{
+			name:    "suggestions",
+			arg:     "deploymnet.spec",
+			wantErr: "did you mean deployment.spec or deployments.spec or deploy.spec?",
+		},
+		{
+			name:    "no lists in suggestions",
+			arg:     "deploymentlis",
+			wantErr: "did you mean deployments or deployment?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvk, fields, err := KindForArg(m, tt.arg, tt.gv)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("KindForArg() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("KindForArg() error = %v", err)
+			}
+			if gvk != tt.wantKind || !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("KindForArg() = %v, %v, want %v, %v", gvk, fields, tt.wantKind, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestResourceNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []Resource
+		want      []string
+	}{
+		{
+			name:      "subresources and lists are skipped when paths are known",
+			resources: testResources()[:3],
+			want:      []string{"deploy", "deployment", "deployments"},
+		},
+		{
+			name:      "lists are skipped without paths",
+			resources: GuessResources([]schema.GroupVersionKind{{Version: "v1", Kind: "Pod"}, {Version: "v1", Kind: "PodList"}, {Version: "v1", Kind: "Binding"}}),
+			want:      []string{"binding", "bindings", "po", "pod", "pods"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResourceNames(NewRawMapper(tt.resources)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package mapper
+
+import (
+	"kexplain/pkg/gvkext"
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const (
+	gvkExtKey    = "x-kubernetes-group-version-kind"
+	actionExtKey = "x-kubernetes-action"
+
+	namespacedPathSegment = "namespaces/{namespace}/"
+)
+
+// Short names are not in swagger, so the well-known ones of built-in resources are kept here.
+var shortNames = map[schema.GroupResource][]string{
+	{Group: "", Resource: "componentstatuses"}:                             {"cs"},
+	{Group: "", Resource: "configmaps"}:                                    {"cm"},
+	{Group: "", Resource: "endpoints"}:                                     {"ep"},
+	{Group: "", Resource: "events"}:                                        {"ev"},
+	{Group: "", Resource: "limitranges"}:                                   {"limits"},
+	{Group: "", Resource: "namespaces"}:                                    {"ns"},
+	{Group: "", Resource: "nodes"}:                                         {"no"},
+	{Group: "", Resource: "persistentvolumeclaims"}:                        {"pvc"},
+	{Group: "", Resource: "persistentvolumes"}:                             {"pv"},
+	{Group: "", Resource: "pods"}:                                          {"po"},
+	{Group: "", Resource: "replicationcontrollers"}:                        {"rc"},
+	{Group: "", Resource: "resourcequotas"}:                                {"quota"},
+	{Group: "", Resource: "serviceaccounts"}:                               {"sa"},
+	{Group: "", Resource: "services"}:                                      {"svc"},
+	{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}: {"crd", "crds"},
+	{Group: "apps", Resource: "daemonsets"}:                                {"ds"},
+	{Group: "apps", Resource: "deployments"}:                               {"deploy"},
+	{Group: "apps", Resource: "replicasets"}:                               {"rs"},
+	{Group: "apps", Resource: "statefulsets"}:                              {"sts"},
+	{Group: "autoscaling", Resource: "horizontalpodautoscalers"}:           {"hpa"},
+	{Group: "batch", Resource: "cronjobs"}:                                 {"cj"},
+	{Group: "certificates.k8s.io", Resource: "certificatesigningrequests"}: {"csr"},
+	{Group: "events.k8s.io", Resource: "events"}:                           {"ev"},
+	{Group: "extensions", Resource: "ingresses"}:                           {"ing"},
+	{Group: "networking.k8s.io", Resource: "ingresses"}:                    {"ing"},
+	{Group: "networking.k8s.io", Resource: "networkpolicies"}:              {"netpol"},
+	{Group: "policy", Resource: "poddisruptionbudgets"}:                    {"pdb"},
+	{Group: "policy", Resource: "podsecuritypolicies"}:                     {"psp"},
+	{Group: "scheduling.k8s.io", Resource: "priorityclasses"}:              {"pc"},
+	{Group: "storage.k8s.io", Resource: "storageclasses"}:                  {"sc"},
+}
+
+// Resource is names of a kind
+type Resource struct {
+	schema.GroupVersionKind
+	Plural     string
+	Singular   string
+	ShortNames []string
+	// nil when it's unknown
+	Namespaced *bool
+}
+
+// GuessResources returns resources with names guessed from kinds
+func GuessResources(gvks []schema.GroupVersionKind) []Resource {
+	resources := make([]Resource, 0, len(gvks))
+	for _, gvk := range gvks {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		resources = append(resources, Resource{
+			GroupVersionKind: gvk,
+			Plural:           plural.Resource,
+			Singular:         singular.Resource,
+			ShortNames:       shortNames[plural.GroupResource()],
+		})
+	}
+	return resources
+}
+
+// TopLevelResources returns resources which users can get, like the ones of `kubectl api-resources`.
+// Kinds without paths like DeploymentList or Scale are skipped when paths are known,
+// otherwise only lists of other kinds are skipped.
+func TopLevelResources(resources []Resource) []Resource {
+	hasPaths := false
+	kinds := map[schema.GroupVersionKind]bool{}
+	for _, r := range resources {
+		hasPaths = hasPaths || r.Namespaced != nil
+		kinds[r.GroupVersionKind] = true
+	}
+	filtered := make([]Resource, 0, len(resources))
+	for _, r := range resources {
+		if hasPaths && r.Namespaced == nil {
+			continue
+		}
+		if item := strings.TrimSuffix(r.Kind, "List"); item != r.Kind && kinds[r.GroupVersion().WithKind(item)] {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// ResourcesFromDocument returns resources of kinds in the document.
+// Plural names come from paths like `/apis/apps/v1/namespaces/{namespace}/deployments`,
+// and are guessed for kinds without paths. gvks are kinds in definitions, see model.Resources.ListResources.
+func ResourcesFromDocument(doc *openapi_v2.Document, gvks []schema.GroupVersionKind) []Resource {
+	plurals := map[schema.GroupVersionKind]string{}
+	namespaced := map[schema.GroupVersionKind]bool{}
+	for _, namedPath := range doc.GetPaths().GetPath() {
+		item := namedPath.GetValue()
+		for _, op := range []*openapi_v2.Operation{item.GetGet(), item.GetPost(), item.GetPut(), item.GetPatch(), item.GetDelete()} {
+			if op == nil {
+				continue
+			}
+			ext := proto.VendorExtensionToMap(op.GetVendorExtension())
+			action, _ := ext[actionExtKey].(string)
+			// watch paths are deprecated and start with a `watch` segment
+			if action == "" || strings.HasPrefix(action, "watch") || action == "connect" {
+				continue
+			}
+			kinds := gvkext.Parse(ext[gvkExtKey])
+			if len(kinds) != 1 {
+				continue
+			}
+			gvk := kinds[0]
+			if plural := pluralOfPath(namedPath.GetName(), gvk); plural != "" {
+				plurals[gvk] = plural
+				namespaced[gvk] = namespaced[gvk] || strings.Contains(namedPath.GetName(), "/"+namespacedPathSegment)
+			}
+		}
+	}
+
+	resources := GuessResources(gvks)
+	for i := range resources {
+		r := &resources[i]
+		if plural, ok := plurals[r.GroupVersionKind]; ok {
+			r.Plural = plural
+			r.ShortNames = shortNames[schema.GroupResource{Group: r.Group, Resource: plural}]
+			ns := namespaced[r.GroupVersionKind]
+			r.Namespaced = &ns
+		}
+	}
+	return resources
+}
+
+// pluralOfPath returns `deployments` for `/apis/apps/v1/namespaces/{namespace}/deployments/{name}`,
+// and empty for subresources like `/apis/apps/v1/namespaces/{namespace}/deployments/{name}/scale`.
+func pluralOfPath(path string, gvk schema.GroupVersionKind) string {
+	prefix := "/apis/" + gvk.GroupVersion().String() + "/"
+	if gvk.Group == "" {
+		prefix = "/api/" + gvk.Version + "/"
+	}
+	if !strings.HasPrefix(path, prefix) {
+		return ""
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, prefix), namespacedPathSegment)
+	segments := strings.Split(rest, "/")
+	if len(segments) > 2 || (len(segments) == 2 && segments[1] != "{name}") {
+		return ""
+	}
+	return segments[0]
+}
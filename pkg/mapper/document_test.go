@@ -0,0 +1,70 @@
+package mapper
+
+import (
+	"kexplain/pkg/testutil"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestPluralOfPath(t *testing.T) {
+	deploy := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	tests := []struct {
+		path string
+		gvk  schema.GroupVersionKind
+		want string
+	}{
+		{path: "/apis/apps/v1/namespaces/{namespace}/deployments/{name}", gvk: deploy, want: "deployments"},
+		{path: "/apis/apps/v1/deployments", gvk: deploy, want: "deployments"},
+		{path: "/apis/apps/v1/namespaces/{namespace}/deployments/{name}/scale", gvk: deploy, want: ""},
+		{path: "/apis/extensions/v1beta1/deployments", gvk: deploy, want: ""},
+		{path: "/api/v1/nodes/{name}", gvk: pod, want: "nodes"},
+		{path: "/api/v1/namespaces/{name}", gvk: pod, want: "namespaces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := pluralOfPath(tt.path, tt.gvk); got != tt.want {
+				t.Errorf("pluralOfPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourcesFromDocument(t *testing.T) {
+	doc := testutil.Document(t, "paths.yaml")
+	namespaced, cluster := true, false
+	tests := []struct {
+		gvk  schema.GroupVersionKind
+		want Resource
+	}{
+		{
+			gvk: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			want: Resource{Plural: "deployments", Singular: "deployment", ShortNames: []string{"deploy"},
+				Namespaced: &namespaced},
+		},
+		{
+			gvk:  schema.GroupVersionKind{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClass"},
+			want: Resource{Plural: "storageclasses", Singular: "storageclass", ShortNames: []string{"sc"}, Namespaced: &cluster},
+		},
+		// kinds without paths are guessed
+		{
+			gvk:  schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"},
+			want: Resource{Plural: "scales", Singular: "scale"},
+		},
+	}
+	gvks := []schema.GroupVersionKind{}
+	for _, tt := range tests {
+		gvks = append(gvks, tt.gvk)
+	}
+	resources := ResourcesFromDocument(doc, gvks)
+	for i, tt := range tests {
+		t.Run(tt.gvk.String(), func(t *testing.T) {
+			tt.want.GroupVersionKind = tt.gvk
+			if got := resources[i]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourcesFromDocument() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,5 +4,9 @@ import "k8s.io/apimachinery/pkg/runtime/schema"
 
 type Mapper interface {
 	// Taken from k8s.io/apimachinery/pkg/api/meta.KindFor
-	KindFor(resource string) (schema.GroupVersionKind, error)
+	// Group and version of the resource are optional, like `deployments` or `deployments.apps`.
+	// *meta.AmbiguousResourceError is returned when it matches kinds in different groups.
+	KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error)
+	// Resources returns all resources known by the mapper
+	Resources() []Resource
 }
@@ -1,20 +1,24 @@
 package mapper
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 )
 
 type K8sMapper struct {
-	mapper meta.RESTMapper
+	mapper    meta.RESTMapper
+	discovery discovery.DiscoveryInterface
 }
 
-func NewK8sMapper(m meta.RESTMapper) *K8sMapper {
-	return &K8sMapper{mapper: m}
+func NewK8sMapper(m meta.RESTMapper, d discovery.DiscoveryInterface) *K8sMapper {
+	return &K8sMapper{mapper: m, discovery: d}
 }
 
-func (m *K8sMapper) KindFor(resource string) (gvk schema.GroupVersionKind, err error) {
-	fullySpecifiedGVR, err := m.mapper.ResourceFor(schema.GroupVersionResource{Resource: resource})
+func (m *K8sMapper) KindFor(resource schema.GroupVersionResource) (gvk schema.GroupVersionKind, err error) {
+	fullySpecifiedGVR, err := m.mapper.ResourceFor(resource)
 	if err != nil {
 		return
 	}
@@ -28,3 +32,30 @@ func (m *K8sMapper) KindFor(resource string) (gvk schema.GroupVersionKind, err e
 	}
 	return
 }
+
+func (m *K8sMapper) Resources() []Resource {
+	// errors of some groups can be ignored, other lists are still returned
+	lists, _ := m.discovery.ServerPreferredResources()
+	resources := []Resource{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			// skip subresources like pods/status
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			namespaced := r.Namespaced
+			resources = append(resources, Resource{
+				GroupVersionKind: gv.WithKind(r.Kind),
+				Plural:           r.Name,
+				Singular:         r.SingularName,
+				ShortNames:       r.ShortNames,
+				Namespaced:       &namespaced,
+			})
+		}
+	}
+	return resources
+}
@@ -2,38 +2,87 @@ package mapper
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
 )
 
-type RawMapper struct{}
-
-func NewRawMapper() *RawMapper {
-	return &RawMapper{}
+// RawMapper maps resources to kinds without k8s server, using resources of the schema document.
+// See ResourcesFromDocument.
+type RawMapper struct {
+	all []Resource
+	// key is lower case kind, plural, singular or short name
+	resources map[string][]Resource
 }
 
-func (r *RawMapper) KindFor(resource string) (schema.GroupVersionKind, error) {
-	idx := -1
-	if i, ok := singularToIndex[resource]; ok {
-		idx = i
+func NewRawMapper(resources []Resource) *RawMapper {
+	m := &RawMapper{all: resources, resources: make(map[string][]Resource)}
+	for _, r := range resources {
+		names := append([]string{r.Kind, r.Plural, r.Singular}, r.ShortNames...)
+		added := map[string]bool{}
+		for _, name := range names {
+			name = strings.ToLower(name)
+			if name != "" && !added[name] {
+				added[name] = true
+				m.resources[name] = append(m.resources[name], r)
+			}
+		}
 	}
-	if i, ok := pluralToIndex[resource]; ok {
-		idx = i
+	return m
+}
+
+func (m *RawMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	candidates := []Resource{}
+	for _, r := range m.resources[strings.ToLower(resource.Resource)] {
+		if (resource.Group == "" || r.Group == resource.Group) && (resource.Version == "" || r.Version == resource.Version) {
+			candidates = append(candidates, r)
+		}
 	}
-	if i, ok := shortToIndex[resource]; ok {
-		idx = i
+	if len(candidates) == 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("not found kind for %s using raw mapper", formatResource(resource))
 	}
-	if i, ok := kindToIndex[resource]; ok {
-		idx = i
+
+	// prefer the core group and then the more stable version, like events or ingresses
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Group == "") != (b.Group == "") {
+			return a.Group == ""
+		}
+		return version.CompareKubeAwareVersionStrings(a.Version, b.Version) > 0
+	})
+	best := candidates[0]
+	ambiguous := false
+	for _, r := range candidates[1:] {
+		if r.Group != best.Group && (r.Group == "") == (best.Group == "") && r.Version == best.Version {
+			ambiguous = true
+		}
 	}
-	if idx < 0 {
-		return schema.GroupVersionKind{}, fmt.Errorf("not found kind for %s using raw mapper", resource)
+	if ambiguous {
+		err := &meta.AmbiguousResourceError{PartialResource: resource}
+		for _, r := range candidates {
+			err.MatchingResources = append(err.MatchingResources, r.GroupVersion().WithResource(r.Plural))
+			err.MatchingKinds = append(err.MatchingKinds, r.GroupVersionKind)
+		}
+		return schema.GroupVersionKind{}, err
 	}
+	return best.GroupVersionKind, nil
+}
 
-	item := resources[idx]
-	gv, err := schema.ParseGroupVersion(item[2])
-	if err != nil {
-		return schema.GroupVersionKind{}, err
+func (m *RawMapper) Resources() []Resource {
+	return m.all
+}
+
+// formatResource returns the kubectl style resource like `deployments.v1.apps`
+func formatResource(r schema.GroupVersionResource) string {
+	s := r.Resource
+	if r.Version != "" {
+		s += "." + r.Version
+	}
+	if r.Group != "" {
+		s += "." + r.Group
 	}
-	return gv.WithKind(item[3]), nil
+	return s
 }
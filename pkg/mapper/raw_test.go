@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRawMapperKindFor(t *testing.T) {
+	m := NewRawMapper(GuessResources([]schema.GroupVersionKind{
+		{Version: "v1", Kind: "Event"},
+		{Group: "events.k8s.io", Version: "v1", Kind: "Event"},
+		{Group: "events.k8s.io", Version: "v1beta1", Kind: "Event"},
+		{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+		{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+		{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress"},
+	}))
+	tests := []struct {
+		resource string
+		want     schema.GroupVersionKind
+		wantErr  bool
+	}{
+		{resource: "event", want: schema.GroupVersionKind{Version: "v1", Kind: "Event"}},
+		{resource: "events", want: schema.GroupVersionKind{Version: "v1", Kind: "Event"}},
+		{resource: "Ingress", want: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}},
+		{resource: "ingresses", want: schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}},
+		{resource: "pods", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			got, err := m.KindFor(schema.GroupVersionResource{Resource: tt.resource})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KindFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("KindFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawMapperShortNames(t *testing.T) {
+	cronTab := schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"}
+	m := NewRawMapper([]Resource{{GroupVersionKind: cronTab, Plural: "crontabs", ShortNames: []string{"ct"}}})
+	for _, name := range []string{"CronTab", "crontabs", "CT"} {
+		if got, err := m.KindFor(schema.GroupVersionResource{Resource: name}); err != nil || got != cronTab {
+			t.Errorf("KindFor(%q) = %v, %v, want %v", name, got, err, cronTab)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const crdKind = "CustomResourceDefinition"
+
+// CRD is the part of a CustomResourceDefinition kexplain needs
+type CRD struct {
+	Group      string
+	Names      CRDNames
+	Namespaced bool
+	Versions   []CRDVersion
+}
+
+// CRDNames is spec.names of a CRD
+type CRDNames struct {
+	Plural     string   `json:"plural"`
+	Singular   string   `json:"singular"`
+	ShortNames []string `json:"shortNames"`
+	Kind       string   `json:"kind"`
+}
+
+// CRDVersion is a served version of a CRD
+type CRDVersion struct {
+	Name   string
+	Schema map[string]interface{}
+}
+
+// GroupVersionKinds returns the GVK of every version
+func (c *CRD) GroupVersionKinds() []schema.GroupVersionKind {
+	gvks := make([]schema.GroupVersionKind, 0, len(c.Versions))
+	for _, v := range c.Versions {
+		gvks = append(gvks, schema.GroupVersionKind{Group: c.Group, Version: v.Name, Kind: c.Names.Kind})
+	}
+	return gvks
+}
+
+type crdSchema struct {
+	OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+}
+
+// crdManifest supports both apiextensions.k8s.io/v1 and v1beta1
+type crdManifest struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Group    string   `json:"group"`
+		Names    CRDNames `json:"names"`
+		Scope    string   `json:"scope"`
+		Version  string   `json:"version"`
+		Versions []struct {
+			Name   string    `json:"name"`
+			Served *bool     `json:"served"`
+			Schema crdSchema `json:"schema"`
+		} `json:"versions"`
+		Validation crdSchema `json:"validation"`
+	} `json:"spec"`
+}
+
+// ParseCRDs parses CRDs from a YAML or JSON stream with one or more documents.
+// Documents which are not CRDs are skipped.
+func ParseCRDs(r io.Reader) ([]*CRD, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	crds := []*CRD{}
+	for {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		jsonData, err := yaml.ToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		var m crdManifest
+		if err := json.Unmarshal(jsonData, &m); err != nil {
+			return nil, err
+		}
+		if m.Kind != crdKind {
+			continue
+		}
+
+		crd := &CRD{
+			Group:      m.Spec.Group,
+			Names:      m.Spec.Names,
+			Namespaced: m.Spec.Scope != "Cluster",
+		}
+		if crd.Names.Singular == "" {
+			crd.Names.Singular = strings.ToLower(crd.Names.Kind)
+		}
+		for _, v := range m.Spec.Versions {
+			if v.Served != nil && !*v.Served {
+				continue
+			}
+			s := v.Schema.OpenAPIV3Schema
+			// v1beta1 can share one schema for all versions
+			if s == nil {
+				s = m.Spec.Validation.OpenAPIV3Schema
+			}
+			crd.Versions = append(crd.Versions, CRDVersion{Name: v.Name, Schema: s})
+		}
+		if len(m.Spec.Versions) == 0 && m.Spec.Version != "" {
+			crd.Versions = append(crd.Versions, CRDVersion{Name: m.Spec.Version, Schema: m.Spec.Validation.OpenAPIV3Schema})
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+// NewDocumentFromCRDs converts the openAPIV3Schema of every CRD version to a v2 document
+func NewDocumentFromCRDs(crds []*CRD) (*openapi_v2.Document, error) {
+	schemas := map[string]map[string]interface{}{}
+	for _, crd := range crds {
+		for _, v := range crd.Versions {
+			s := v.Schema
+			if s == nil {
+				// a CRD without schema accepts anything
+				s = map[string]interface{}{"type": "object", "x-kubernetes-preserve-unknown-fields": true}
+			}
+			s = completeCRDSchema(s)
+			s[gvkExtKey] = []interface{}{map[string]interface{}{
+				"group":   crd.Group,
+				"version": v.Name,
+				"kind":    crd.Names.Kind,
+			}}
+			schemas[crdDefinitionName(crd.Group, v.Name, crd.Names.Kind)] = s
+		}
+	}
+	return newDocumentFromV3Schemas("", map[string]interface{}{}, schemas)
+}
+
+// completeCRDSchema adds apiVersion and kind like what k8s server does
+func completeCRDSchema(s map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(s)+1)
+	for k, v := range s {
+		result[k] = v
+	}
+	props, ok := s["properties"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+	newProps := make(map[string]interface{}, len(props)+2)
+	for k, v := range props {
+		newProps[k] = v
+	}
+	if _, ok := newProps["apiVersion"]; !ok {
+		newProps["apiVersion"] = map[string]interface{}{
+			"type":        "string",
+			"description": "APIVersion defines the versioned schema of this representation of an object.",
+		}
+	}
+	if _, ok := newProps["kind"]; !ok {
+		newProps["kind"] = map[string]interface{}{
+			"type":        "string",
+			"description": "Kind is a string value representing the REST resource this object represents.",
+		}
+	}
+	result["properties"] = newProps
+	return result
+}
+
+// crdDefinitionName returns name like `com.example.stable.v1.CronTab` for group `stable.example.com`,
+// which is the same as definitions of CRDs in k8s server.
+func crdDefinitionName(group, version, kind string) string {
+	parts := strings.Split(group, ".")
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(append(parts, version, kind), ".")
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"bytes"
+	"kexplain/pkg/testutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/explain"
+)
+
+func TestParseCRDs(t *testing.T) {
+	crds, err := ParseCRDs(bytes.NewReader(testutil.ReadFile(t, "crds.yaml")))
+	if err != nil {
+		t.Fatalf("ParseCRDs() error = %v", err)
+	}
+	tests := []struct {
+		kind       string
+		names      CRDNames
+		namespaced bool
+		gvks       []schema.GroupVersionKind
+	}{
+		{
+			kind:       "CronTab",
+			names:      CRDNames{Plural: "crontabs", Singular: "crontab", ShortNames: []string{"ct"}, Kind: "CronTab"},
+			namespaced: true,
+			// versions not served are skipped
+			gvks: []schema.GroupVersionKind{{Group: "stable.example.com", Version: "v1", Kind: "CronTab"}},
+		},
+		{
+			kind:  "Widget",
+			names: CRDNames{Plural: "widgets", Singular: "widget", Kind: "Widget"},
+			gvks: []schema.GroupVersionKind{
+				{Group: "example.com", Version: "v1beta1", Kind: "Widget"},
+				{Group: "example.com", Version: "v1beta2", Kind: "Widget"},
+			},
+		},
+		{
+			kind:       "Gadget",
+			names:      CRDNames{Plural: "gadgets", Singular: "gadget", Kind: "Gadget"},
+			namespaced: true,
+			gvks:       []schema.GroupVersionKind{{Group: "example.com", Version: "v1", Kind: "Gadget"}},
+		},
+	}
+	if len(crds) != len(tests) {
+		t.Fatalf("ParseCRDs() returns %d CRDs, want %d", len(crds), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			crd := crds[i]
+			if !reflect.DeepEqual(crd.Names, tt.names) || crd.Namespaced != tt.namespaced {
+				t.Errorf("names = %+v, namespaced = %v, want %+v, %v", crd.Names, crd.Namespaced, tt.names, tt.namespaced)
+			}
+			if got := crd.GroupVersionKinds(); !reflect.DeepEqual(got, tt.gvks) {
+				t.Errorf("GroupVersionKinds() = %v, want %v", got, tt.gvks)
+			}
+		})
+	}
+	// v1beta1 versions share the schema of validation
+	for _, v := range crds[1].Versions {
+		if v.Schema == nil {
+			t.Errorf("schema of Widget %s is nil", v.Name)
+		}
+	}
+}
+
+func TestNewDocumentFromCRDs(t *testing.T) {
+	crds, err := ParseCRDs(bytes.NewReader(testutil.ReadFile(t, "crds.yaml")))
+	if err != nil {
+		t.Fatalf("ParseCRDs() error = %v", err)
+	}
+	doc, err := NewDocumentFromCRDs(crds)
+	if err != nil {
+		t.Fatalf("NewDocumentFromCRDs() error = %v", err)
+	}
+	r, err := NewResources(doc)
+	if err != nil {
+		t.Fatalf("NewResources() error = %v", err)
+	}
+	wantGVKs := []schema.GroupVersionKind{
+		{Group: "example.com", Version: "v1", Kind: "Gadget"},
+		{Group: "example.com", Version: "v1beta1", Kind: "Widget"},
+		{Group: "example.com", Version: "v1beta2", Kind: "Widget"},
+		{Group: "stable.example.com", Version: "v1", Kind: "CronTab"},
+	}
+	if got := r.ListResources(); !reflect.DeepEqual(got, wantGVKs) {
+		t.Errorf("ListResources() = %v, want %v", got, wantGVKs)
+	}
+
+	cronTab := wantGVKs[3]
+	if got := r.LookupResource(cronTab).GetPath().String(); got != "com.example.stable.v1.CronTab" {
+		t.Errorf("definition name = %q, want com.example.stable.v1.CronTab", got)
+	}
+	tests := []struct {
+		gvk        schema.GroupVersionKind
+		fieldsPath []string
+		wantType   string
+	}{
+		// apiVersion and kind are added like k8s server
+		{gvk: cronTab, fieldsPath: []string{"apiVersion"}, wantType: "string"},
+		{gvk: cronTab, fieldsPath: []string{"kind"}, wantType: "string"},
+		{gvk: cronTab, fieldsPath: []string{"spec", "cronSpec"}, wantType: "string"},
+		{gvk: cronTab, fieldsPath: []string{"spec", "replicas"}, wantType: "integer"},
+		// objects without properties accept any fields
+		{gvk: wantGVKs[1], fieldsPath: []string{"spec"}, wantType: "map[string]"},
+		// a CRD without schema accepts anything
+		{gvk: wantGVKs[0], fieldsPath: nil, wantType: "map[string]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gvk.Kind+"."+strings.Join(tt.fieldsPath, "."), func(t *testing.T) {
+			doc := newTestDoc(t, r, tt.gvk, tt.fieldsPath...)
+			if got := explain.GetTypeName(doc.field); got != tt.wantType {
+				t.Errorf("type = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+	if got := newTestDoc(t, r, cronTab, "spec", "replicas").GetDetails(); !reflect.DeepEqual(got, []string{"Minimum: 1"}) {
+		t.Errorf("GetDetails() = %q, want minimum", got)
+	}
+}
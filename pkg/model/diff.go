@@ -0,0 +1,155 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/kubectl/pkg/explain"
+)
+
+// ChangeType is the type of a FieldChange
+type ChangeType string
+
+const (
+	FieldAdded              ChangeType = "added"
+	FieldRemoved            ChangeType = "removed"
+	FieldRetyped            ChangeType = "retyped"
+	FieldDescriptionChanged ChangeType = "description"
+)
+
+// FieldChange is a change of a field between two schemas
+type FieldChange struct {
+	// Path is like `spec.replicas`
+	Path           string
+	Change         ChangeType
+	OldType        string
+	NewType        string
+	OldDescription string
+	NewDescription string
+}
+
+// KindDiff is changes of a kind between two schemas
+type KindDiff struct {
+	schema.GroupVersionKind
+	// OldGroupVersion is the one of the kind in the old schema if it's not the same, like `batch/v1beta1` of CronJob
+	OldGroupVersion schema.GroupVersion
+	// Added or Removed is true if the kind is only in one schema
+	Added   bool
+	Removed bool
+	Changes []FieldChange
+}
+
+// DiffResources returns changed kinds between two schemas, sorted by group, version and kind
+func DiffResources(from, to *Resources) []KindDiff {
+	gvks := map[schema.GroupVersionKind]bool{}
+	for _, gvk := range from.ListResources() {
+		gvks[gvk] = true
+	}
+	for _, gvk := range to.ListResources() {
+		gvks[gvk] = true
+	}
+	sorted := make([]schema.GroupVersionKind, 0, len(gvks))
+	for gvk := range gvks {
+		sorted = append(sorted, gvk)
+	}
+	sortGVKs(sorted)
+
+	diffs := []KindDiff{}
+	for _, gvk := range sorted {
+		// changes of lists are the same as their items
+		if item := gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List")); item != gvk && gvks[item] {
+			continue
+		}
+		oldSchema, newSchema := from.LookupResource(gvk), to.LookupResource(gvk)
+		switch {
+		case oldSchema == nil:
+			diffs = append(diffs, KindDiff{GroupVersionKind: gvk, Added: true})
+		case newSchema == nil:
+			diffs = append(diffs, KindDiff{GroupVersionKind: gvk, Removed: true})
+		default:
+			if changes := DiffSchemas(oldSchema, newSchema, ""); len(changes) > 0 {
+				diffs = append(diffs, KindDiff{GroupVersionKind: gvk, Changes: changes})
+			}
+		}
+	}
+	return diffs
+}
+
+// DiffSchemas returns changes of fields from the old schema to the new one recursively.
+// path is the path of the schemas, which prefixes paths of changes.
+func DiffSchemas(oldSchema, newSchema proto.Schema, path string) []FieldChange {
+	d := &differ{parents: map[*proto.Kind]bool{}}
+	d.diffFields(oldSchema, newSchema, path)
+	return d.changes
+}
+
+// DiffDocs returns changes of fields from the old doc to the new one, which are docs of the same path
+func DiffDocs(oldDoc, newDoc *Doc) []FieldChange {
+	return DiffSchemas(oldDoc.field, newDoc.field, strings.Join(newDoc.fieldsPath, "."))
+}
+
+type differ struct {
+	changes []FieldChange
+	// kinds of the old schema being compared, to stop at recursive types
+	parents map[*proto.Kind]bool
+}
+
+func (d *differ) diffFields(oldSchema, newSchema proto.Schema, path string) {
+	oldKind, ok := elemSchema(oldSchema).(*proto.Kind)
+	if !ok || d.parents[oldKind] {
+		return
+	}
+	newKind, ok := elemSchema(newSchema).(*proto.Kind)
+	if !ok {
+		return
+	}
+	d.parents[oldKind] = true
+	defer delete(d.parents, oldKind)
+
+	keys := map[string]bool{}
+	for _, key := range oldKind.Keys() {
+		keys[key] = true
+	}
+	for _, key := range newKind.Keys() {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		fieldPath := joinPath(path, key)
+		oldField, inOld := oldKind.Fields[key]
+		newField, inNew := newKind.Fields[key]
+		switch {
+		case !inOld:
+			d.changes = append(d.changes, FieldChange{
+				Path: fieldPath, Change: FieldAdded,
+				NewType: explain.GetTypeName(newField), NewDescription: newField.GetDescription(),
+			})
+		case !inNew:
+			d.changes = append(d.changes, FieldChange{
+				Path: fieldPath, Change: FieldRemoved,
+				OldType: explain.GetTypeName(oldField), OldDescription: oldField.GetDescription(),
+			})
+		default:
+			oldType, newType := explain.GetTypeName(oldField), explain.GetTypeName(newField)
+			change := FieldChange{
+				Path: fieldPath, OldType: oldType, NewType: newType,
+				OldDescription: oldField.GetDescription(), NewDescription: newField.GetDescription(),
+			}
+			if oldType != newType {
+				change.Change = FieldRetyped
+				d.changes = append(d.changes, change)
+			} else if change.OldDescription != change.NewDescription {
+				change.Change = FieldDescriptionChanged
+				d.changes = append(d.changes, change)
+			}
+			d.diffFields(oldField, newField, fieldPath)
+		}
+	}
+}
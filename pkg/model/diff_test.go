@@ -0,0 +1,53 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	oldResources := newTestResources(t, "deployment.yaml")
+	newResources := newTestResources(t, "deployment-changed.yaml")
+	oldSchema, newSchema := oldResources.LookupResource(testDeploymentGVK), newResources.LookupResource(testDeploymentGVK)
+
+	tests := []struct {
+		name string
+		path string
+		want []FieldChange
+	}{
+		{
+			name: "kind",
+			want: []FieldChange{
+				{Path: "spec.replicas", Change: FieldRetyped, OldType: "integer", NewType: "string",
+					OldDescription: "Number of desired pods. Defaults to 1.", NewDescription: "Number of desired pods. Defaults to 1."},
+				{Path: "spec.selector", Change: FieldDescriptionChanged, OldType: "Object", NewType: "Object",
+					OldDescription: "Label selector for pods.", NewDescription: "Label selector for pods of the deployment."},
+				{Path: "spec.template.spec.containers.tty", Change: FieldAdded, NewType: "boolean"},
+				{Path: "spec.template.spec.nodeName", Change: FieldRemoved, OldType: "string"},
+			},
+		},
+		{
+			name: "prefixed by path",
+			path: "deployment",
+			want: []FieldChange{
+				{Path: "deployment.spec.replicas", Change: FieldRetyped, OldType: "integer", NewType: "string",
+					OldDescription: "Number of desired pods. Defaults to 1.", NewDescription: "Number of desired pods. Defaults to 1."},
+				{Path: "deployment.spec.selector", Change: FieldDescriptionChanged, OldType: "Object", NewType: "Object",
+					OldDescription: "Label selector for pods.", NewDescription: "Label selector for pods of the deployment."},
+				{Path: "deployment.spec.template.spec.containers.tty", Change: FieldAdded, NewType: "boolean"},
+				{Path: "deployment.spec.template.spec.nodeName", Change: FieldRemoved, OldType: "string"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffSchemas(oldSchema, newSchema, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffSchemas() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := DiffSchemas(oldSchema, oldSchema, ""); len(got) != 0 {
+		t.Errorf("DiffSchemas() of the same schema = %+v, want none", got)
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"kexplain/pkg/suggest"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -31,7 +32,7 @@ func NewDoc(schema proto.Schema, fieldsPath []string, gvk schema.GroupVersionKin
 
 	field, err := explain.LookupSchemaForField(schema, fieldsPath)
 	if err != nil {
-		return nil, err
+		return nil, withFieldSuggestions(err, schema, fieldsPath, gvk)
 	}
 	subSchema := findFieldSchema(field)
 
@@ -45,15 +46,52 @@ func NewDoc(schema proto.Schema, fieldsPath []string, gvk schema.GroupVersionKin
 	}, nil
 }
 
+// withFieldSuggestions adds fields close to the first invalid field in the path to the error
+func withFieldSuggestions(err error, s proto.Schema, fieldsPath []string, gvk schema.GroupVersionKind) error {
+	for i := range fieldsPath {
+		parent, lookupErr := explain.LookupSchemaForField(s, fieldsPath[:i])
+		if lookupErr != nil {
+			return err
+		}
+		if _, lookupErr = explain.LookupSchemaForField(s, fieldsPath[:i+1]); lookupErr == nil {
+			continue
+		}
+
+		kind, ok := findFieldSchema(parent).(*proto.Kind)
+		if !ok {
+			return err
+		}
+		prefix := strings.Join(append([]string{strings.ToLower(gvk.Kind)}, fieldsPath[:i]...), ".")
+		suggestions := suggest.Closest(fieldsPath[i], kind.Keys())
+		if len(suggestions) == 0 {
+			return fmt.Errorf("field %q does not exist in %s", fieldsPath[i], prefix)
+		}
+		for j := range suggestions {
+			suggestions[j] = prefix + "." + suggestions[j]
+		}
+		return fmt.Errorf("field %q does not exist in %s, did you mean %s?", fieldsPath[i], prefix, strings.Join(suggestions, " or "))
+	}
+	return err
+}
+
 func findFieldSchema(field proto.Schema) proto.Schema {
+	// elements of arrays and values of maps like `resources.limits`, which can be nested in CRDs
+	for unwrapped := true; unwrapped; {
+		switch t := field.(type) {
+		case *proto.Array:
+			field = t.SubType
+		case *proto.Map:
+			field = t.SubType
+		default:
+			unwrapped = false
+		}
+	}
 	if subTypeRef, ok := field.(*proto.Ref); ok {
 		return subTypeRef.SubSchema()
 	}
-	if fieldArray, ok := field.(*proto.Array); ok {
-		subType := fieldArray.SubType
-		if subTypeRef, ok := subType.(*proto.Ref); ok {
-			return subTypeRef.SubSchema()
-		}
+	// inline object like ones in CRDs
+	if kind, ok := field.(*proto.Kind); ok {
+		return kind
 	}
 	return nil
 }
@@ -66,6 +104,15 @@ func (d *Doc) GetVersion() string {
 	return d.gvk.Version
 }
 
+func (d *Doc) GetGroupVersionKind() schema.GroupVersionKind {
+	return d.gvk
+}
+
+// GetFieldsPath returns path of the field in the resource, like `["spec", "template"]` for `deploy.spec.template`
+func (d *Doc) GetFieldsPath() []string {
+	return d.fieldsPath
+}
+
 func (d *Doc) GetFieldResource() string {
 	if d.fieldType == "" {
 		d.fieldType = explain.GetTypeName(d.field)
@@ -76,9 +123,33 @@ func (d *Doc) GetFieldResource() string {
 	return fmt.Sprintf("%s <%s>", d.fieldName, d.fieldType)
 }
 
+// GetDetails returns extra information of the field and its ref schema like default and nullable
+func (d *Doc) GetDetails() []string {
+	if d.fieldName == "" {
+		return nil
+	}
+	details := SchemaDetails(d.field)
+	if m, ok := d.field.(*proto.Map); ok {
+		details = append([]string{fmt.Sprintf("Map: keys are <string>, values are <%s>", explain.GetTypeName(m.SubType))}, details...)
+	}
+	if d.fieldRefSchema != nil && d.fieldRefSchema != d.field {
+		// formats of refs are the ones of the ref schemas
+		seen := map[string]bool{}
+		for _, detail := range details {
+			seen[detail] = true
+		}
+		for _, detail := range SchemaDetails(d.fieldRefSchema) {
+			if !seen[detail] {
+				details = append(details, detail)
+			}
+		}
+	}
+	return details
+}
+
 func (d *Doc) GetDescriptions() []string {
 	desc := []string{d.field.GetDescription()}
-	if d.fieldRefSchema != nil {
+	if d.fieldRefSchema != nil && d.fieldRefSchema != d.field {
 		desc = append(desc, d.fieldRefSchema.GetDescription())
 	}
 	return desc
@@ -137,6 +208,33 @@ func (d *Doc) FindSubDoc(fieldIdx int) *Doc {
 	return newDoc
 }
 
+// FindFieldDoc returns the doc of a field path relative to the doc, like `template.spec` for `deploy.spec`,
+// or nil if the field is not an object
+func (d *Doc) FindFieldDoc(path []string) *Doc {
+	newDoc, err := NewDoc(d.schema, append(append([]string{}, d.fieldsPath...), path...), d.gvk)
+	if err != nil || newDoc.fieldRefSchema == nil {
+		return nil
+	}
+	return newDoc
+}
+
+// FindPathDoc returns the doc of a path in the resource of the doc, like `["spec", "replicas"]`
+func (d *Doc) FindPathDoc(path []string) (*Doc, error) {
+	return NewDoc(d.schema, path, d.gvk)
+}
+
+// FindRootDoc returns the doc of the resource, like `deploy` for `deploy.spec.template`
+func (d *Doc) FindRootDoc() *Doc {
+	if len(d.fieldsPath) == 0 {
+		return d
+	}
+	newDoc, err := NewDoc(d.schema, nil, d.gvk)
+	if err != nil {
+		return d
+	}
+	return newDoc
+}
+
 // FindParentDoc returns parent doc, like `deploy.spec` for `deploy.spec.template`
 func (d *Doc) FindParentDoc() *Doc {
 	if len(d.fieldsPath) == 0 {
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMapFields(t *testing.T) {
+	r := newTestResources(t, "maps.yaml")
+	gvk := schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Config"}
+	tests := []struct {
+		fieldsPath  []string
+		wantDetails []string
+	}{
+		{fieldsPath: []string{"services"}, wantDetails: []string{"Map: keys are <string>, values are <Object>"}},
+		{fieldsPath: []string{"labels"}, wantDetails: []string{"Map: keys are <string>, values are <string>"}},
+		{fieldsPath: []string{"services", "port"}, wantDetails: []string{}},
+		// values of maps can be arrays
+		{fieldsPath: []string{"groups", "port"}, wantDetails: []string{}},
+	}
+	for _, tt := range tests {
+		doc := newTestDoc(t, r, gvk, tt.fieldsPath...)
+		if got := doc.GetDetails(); !reflect.DeepEqual(got, tt.wantDetails) {
+			t.Errorf("GetDetails() of %s = %q, want %q", doc.GetFullPath(), got, tt.wantDetails)
+		}
+	}
+
+	navigable := map[string]bool{}
+	for _, f := range newTestDoc(t, r, gvk).Output().Fields {
+		navigable[f.Name] = f.Navigable
+	}
+	if want := map[string]bool{"groups": true, "labels": false, "services": true}; !reflect.DeepEqual(navigable, want) {
+		t.Errorf("navigable fields = %v, want %v", navigable, want)
+	}
+}
+
+func TestNewDocFieldSuggestions(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	tests := []struct {
+		fieldsPath []string
+		want       string
+	}{
+		{fieldsPath: []string{"spec", "replica"}, want: `field "replica" does not exist in deployment.spec, did you mean deployment.spec.replicas?`},
+		{fieldsPath: []string{"sepc", "replicas"}, want: `field "sepc" does not exist in deployment, did you mean deployment.spec?`},
+		{fieldsPath: []string{"spec", "zzzzzzzz"}, want: `field "zzzzzzzz" does not exist in deployment.spec`},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.fieldsPath, "."), func(t *testing.T) {
+			_, err := NewDoc(r.LookupResource(testDeploymentGVK), tt.fieldsPath, testDeploymentGVK)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("NewDoc() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
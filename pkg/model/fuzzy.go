@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch returns the score and positions of runes of s matching the pattern in order ignoring case,
+// like `readOnlyRoot` or `rorf` for `spec.securityContext.readOnlyRootFilesystem`.
+// The score is higher for matches at the start of words and consecutive ones in shorter s.
+// Positions are nil when s doesn't match.
+func FuzzyMatch(pattern string, s string) (int, []int) {
+	p := []rune(strings.ToLower(pattern))
+	runes := []rune(s)
+	if len(p) == 0 {
+		return 0, []int{}
+	}
+
+	// match from the end, so that the last field is preferred, like `name` in `containers.name`
+	positions := make([]int, len(p))
+	pi := len(p) - 1
+	for i := len(runes) - 1; i >= 0 && pi >= 0; i-- {
+		if unicode.ToLower(runes[i]) == p[pi] {
+			positions[pi] = i
+			pi--
+		}
+	}
+	if pi >= 0 {
+		return 0, nil
+	}
+	// move matches forward to make them consecutive where possible, like `rorf` in `readOnlyRootFilesystem`
+	for i := len(p) - 2; i >= 0; i-- {
+		for j := positions[i+1] - 1; j > positions[i]; j-- {
+			if unicode.ToLower(runes[j]) == p[i] && (isWordStart(runes, j) || j == positions[i+1]-1) {
+				positions[i] = j
+				break
+			}
+		}
+	}
+	// and move later ones backward after the previous ones, like the last `t` of `readOnlyRoot`
+	for i := 1; i < len(p); i++ {
+		if next := positions[i-1] + 1; next < positions[i] && unicode.ToLower(runes[next]) == p[i] {
+			positions[i] = next
+		}
+	}
+
+	score := 0
+	for i, pos := range positions {
+		score += 1
+		if isWordStart(runes, pos) {
+			score += 8
+		}
+		if i > 0 && positions[i-1] == pos-1 {
+			score += 5
+		}
+	}
+	return score*100 - len(runes), positions
+}
+
+// isWordStart returns true for the first rune, runes after `.` and upper case runes in camel case
+func isWordStart(runes []rune, i int) bool {
+	if i == 0 || runes[i-1] == '.' {
+		return true
+	}
+	return unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1])
+}
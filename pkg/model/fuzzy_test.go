@@ -0,0 +1,53 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		s         string
+		positions []int
+	}{
+		{pattern: "rorf", s: "spec.securityContext.readOnlyRootFilesystem", positions: []int{21, 25, 29, 33}},
+		{pattern: "readOnlyRoot", s: "spec.securityContext.readOnlyRootFilesystem",
+			positions: []int{21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}},
+		{pattern: "name", s: "containers.name", positions: []int{11, 12, 13, 14}},
+		{pattern: "IMG", s: "image", positions: []int{0, 1, 3}},
+		{pattern: "xyz", s: "containers.name", positions: nil},
+		{pattern: "", s: "image", positions: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" in "+tt.s, func(t *testing.T) {
+			if _, positions := FuzzyMatch(tt.pattern, tt.s); !reflect.DeepEqual(positions, tt.positions) {
+				t.Errorf("FuzzyMatch() positions = %v, want %v", positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchScore(t *testing.T) {
+	tests := []struct {
+		pattern string
+		// better is scored higher than worse
+		better, worse string
+	}{
+		// word starts
+		{pattern: "rorf", better: "readOnlyRootFilesystem", worse: "errorfile"},
+		// consecutive
+		{pattern: "name", better: "metadata.name", worse: "metadata.namespace.e"},
+		// shorter
+		{pattern: "name", better: "metadata.name", worse: "spec.template.metadata.name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			better, _ := FuzzyMatch(tt.pattern, tt.better)
+			worse, _ := FuzzyMatch(tt.pattern, tt.worse)
+			if better <= worse {
+				t.Errorf("FuzzyMatch() score of %s = %d, not higher than %d of %s", tt.better, better, worse, tt.worse)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// Extensions of how server-side apply and strategic merge patch treat fields
+const (
+	listTypeExtKey      = "x-kubernetes-list-type"
+	listMapKeysExtKey   = "x-kubernetes-list-map-keys"
+	patchMergeKeyExtKey = "x-kubernetes-patch-merge-key"
+	patchStrategyExtKey = "x-kubernetes-patch-strategy"
+	mapTypeExtKey       = "x-kubernetes-map-type"
+)
+
+// MergeSemantics returns lines of how server-side apply and strategic merge patch treat the schema,
+// like `List type: map, items are merged by keys: name`. Extensions of referenced schemas are included.
+func MergeSemantics(s proto.Schema) []string {
+	if s == nil {
+		return nil
+	}
+	ext := map[string]interface{}{}
+	if ref, ok := s.(*proto.Ref); ok && ref.SubSchema() != nil {
+		for key, value := range ref.SubSchema().GetExtensions() {
+			ext[key] = value
+		}
+	}
+	// extensions of the field win over the ones of the ref schema
+	for key, value := range s.GetExtensions() {
+		ext[key] = value
+	}
+
+	lines := []string{}
+	switch listType, _ := ext[listTypeExtKey].(string); listType {
+	case "":
+	case "atomic":
+		lines = append(lines, "List type: atomic, the list is replaced as a whole")
+	case "set":
+		lines = append(lines, "List type: set, items are merged as unique scalars")
+	case "map":
+		line := "List type: map, items are merged by keys"
+		if keys := stringList(ext[listMapKeysExtKey]); len(keys) > 0 {
+			line += ": " + strings.Join(keys, ", ")
+		}
+		lines = append(lines, line)
+	default:
+		lines = append(lines, "List type: "+listType)
+	}
+	if strategy, _ := ext[patchStrategyExtKey].(string); strategy != "" {
+		line := "Patch strategy: " + strings.ReplaceAll(strategy, ",", ", ")
+		if key, _ := ext[patchMergeKeyExtKey].(string); key != "" {
+			line += ", merge key: " + key
+		}
+		lines = append(lines, line)
+	} else if key, _ := ext[patchMergeKeyExtKey].(string); key != "" {
+		lines = append(lines, "Patch merge key: "+key)
+	}
+	switch mapType, _ := ext[mapTypeExtKey].(string); mapType {
+	case "":
+	case "atomic":
+		lines = append(lines, "Map type: atomic, the object is replaced as a whole")
+	case "granular":
+		lines = append(lines, "Map type: granular, fields are merged separately")
+	default:
+		lines = append(lines, "Map type: "+mapType)
+	}
+	if preserve, _ := ext[preserveUnknownFieldsExtKey].(bool); preserve {
+		lines = append(lines, "Preserve unknown fields: true, unknown fields are kept instead of pruned")
+	}
+	if intOrString, _ := ext[intOrStringExtKey].(bool); intOrString || schemaFormat(s) == "int-or-string" {
+		lines = append(lines, "Int or string: true, either an integer or a string is accepted")
+	}
+	return lines
+}
+
+// GetMergeSemantics returns how server-side apply and strategic merge patch treat the field
+func (d *Doc) GetMergeSemantics() []string {
+	lines := MergeSemantics(d.field)
+	if d.fieldRefSchema != nil && d.fieldRefSchema != d.field {
+		seen := map[string]bool{}
+		for _, line := range lines {
+			seen[line] = true
+		}
+		for _, line := range MergeSemantics(d.fieldRefSchema) {
+			if !seen[line] {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
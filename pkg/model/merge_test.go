@@ -0,0 +1,46 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetMergeSemantics(t *testing.T) {
+	r := newTestResources(t, "merge.yaml")
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{
+			field: "containers",
+			want:  []string{"List type: map, items are merged by keys: name", "Patch strategy: merge, merge key: name"},
+		},
+		{
+			field: "finalizers",
+			want:  []string{"List type: set, items are merged as unique scalars", "Patch strategy: merge, retainKeys"},
+		},
+		{
+			// extensions of the referenced definition
+			field: "selector",
+			want:  []string{"Map type: atomic, the object is replaced as a whole"},
+		},
+		{
+			field: "port",
+			want:  []string{"Int or string: true, either an integer or a string is accepted"},
+		},
+		{
+			field: "name",
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := newTestDoc(t, r, pod, tt.field).GetMergeSemantics(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetMergeSemantics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
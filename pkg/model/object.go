@@ -0,0 +1,124 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/util/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// ObjectLine is a line of an object in YAML
+type ObjectLine struct {
+	Text string
+	// FieldsPath is the path of the field of the key in the line to explain.
+	// Keys of maps like labels use the path of the map.
+	FieldsPath []string
+}
+
+// ObjectLines returns the object in YAML like `kubectl get -o yaml` line by line,
+// with fields paths of keys resolved through the schema of the object
+func ObjectLines(obj map[string]interface{}, s proto.Schema) []ObjectLine {
+	w := &objectWriter{}
+	w.writeMap(obj, s, nil, "", "")
+	return w.lines
+}
+
+type objectWriter struct {
+	lines []ObjectLine
+}
+
+func (w *objectWriter) line(text string, path []string) {
+	w.lines = append(w.lines, ObjectLine{Text: text, FieldsPath: path})
+}
+
+// writeMap writes keys of m, the first line is prefixed with first like "- " of list items
+func (w *objectWriter) writeMap(m map[string]interface{}, s proto.Schema, path []string, indent, first string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		prefix := indent
+		if i == 0 {
+			prefix = first
+		}
+		subSchema, subPath := objectField(s, path, k)
+		w.writeValue(prefix+yamlScalar(k)+":", m[k], subSchema, subPath, indent)
+	}
+}
+
+// writeValue writes the value after the key line, children are indented more than indent
+func (w *objectWriter) writeValue(key string, v interface{}, s proto.Schema, path []string, indent string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			w.line(key+" {}", path)
+			return
+		}
+		w.line(key, path)
+		w.writeMap(v, s, path, indent+"  ", indent+"  ")
+	case []interface{}:
+		if len(v) == 0 {
+			w.line(key+" []", path)
+			return
+		}
+		w.line(key, path)
+		var item proto.Schema
+		if array, ok := resolveRef(s).(*proto.Array); ok {
+			item = array.SubType
+		}
+		w.writeItems(v, item, path, indent)
+	default:
+		w.line(key+" "+yamlScalar(v), path)
+	}
+}
+
+// writeItems writes list items at indent like `kubectl get -o yaml`
+func (w *objectWriter) writeItems(items []interface{}, s proto.Schema, path []string, indent string) {
+	for _, item := range items {
+		switch item := item.(type) {
+		case map[string]interface{}:
+			if len(item) == 0 {
+				w.line(indent+"- {}", path)
+				continue
+			}
+			w.writeMap(item, s, path, indent+"  ", indent+"- ")
+		case []interface{}:
+			w.writeValue(indent+"-", item, s, path, indent+"  ")
+		default:
+			w.line(indent+"- "+yamlScalar(item), path)
+		}
+	}
+}
+
+// objectField returns the schema and the fields path of key k in the object of schema s
+func objectField(s proto.Schema, path []string, k string) (proto.Schema, []string) {
+	switch s := resolveRef(s).(type) {
+	case *proto.Kind:
+		// unknown fields keep the path to be reported when explaining it
+		return s.Fields[k], appendPath(path, k)
+	case *proto.Map:
+		return s.SubType, path
+	}
+	return nil, path
+}
+
+func appendPath(path []string, k string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), k)
+}
+
+func yamlScalar(v interface{}) string {
+	if s, ok := v.(string); ok && strings.Contains(s, "\n") {
+		// keep it in one line, JSON strings are valid in YAML
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
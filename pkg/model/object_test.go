@@ -0,0 +1,47 @@
+package model
+
+import (
+	"kexplain/pkg/testutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestObjectLines(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(testutil.ReadFile(t, "deployment-object.yaml"), &obj); err != nil {
+		t.Fatalf("fail to parse object: %v", err)
+	}
+	got := []string{}
+	for _, l := range ObjectLines(obj, r.LookupResource(testDeploymentGVK)) {
+		got = append(got, l.Text+"  # "+strings.Join(l.FieldsPath, "."))
+	}
+	want := []string{
+		"apiVersion: apps/v1  # apiVersion",
+		"kind: Deployment  # kind",
+		"metadata:  # metadata",
+		"  creationTimestamp: null  # metadata.creationTimestamp",
+		"  name: nginx  # metadata.name",
+		"spec:  # spec",
+		"  replicas: 2  # spec.replicas",
+		"  selector:  # spec.selector",
+		"    matchLabels:  # spec.selector.matchLabels",
+		// keys of maps use the path of the map
+		"      app: nginx  # spec.selector.matchLabels",
+		"  template:  # spec.template",
+		"    spec:  # spec.template.spec",
+		"      containers:  # spec.template.spec.containers",
+		"      - image: nginx  # spec.template.spec.containers.image",
+		"        name: nginx  # spec.template.spec.containers.name",
+		"        ports:  # spec.template.spec.containers.ports",
+		"        - containerPort: 80  # spec.template.spec.containers.ports.containerPort",
+		// unknown fields keep the path
+		"        unknown: {}  # spec.template.spec.containers.unknown",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ObjectLines() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
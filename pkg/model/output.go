@@ -0,0 +1,87 @@
+package model
+
+import (
+	"k8s.io/kubectl/pkg/explain"
+)
+
+// DocOutput is the structured doc for `-o json` and `-o yaml`.
+// Fields are only added to it to keep it stable for tools.
+type DocOutput struct {
+	// Kind is like `Deployment`
+	Kind string `json:"kind"`
+	// Group is empty for the core group
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	// NewerVersion is like `batch/v1` when the version is deprecated by it
+	NewerVersion string `json:"newerVersion,omitempty"`
+	// Path is the full path like `deployment.spec.template`
+	Path string `json:"path"`
+	// Type is the type of the field like `Object` or `[]string`
+	Type string `json:"type"`
+	// Badges are like `deprecated`, `alpha` and `feature-gated`, which are parsed from descriptions
+	Badges []string `json:"badges,omitempty"`
+	// Descriptions are of the field and of its type
+	Descriptions []string `json:"descriptions"`
+	// Details are extra information like default and nullable
+	Details []string `json:"details,omitempty"`
+	// MergeSemantics are how server-side apply and strategic merge patch treat the field like list types
+	MergeSemantics []string      `json:"mergeSemantics,omitempty"`
+	Fields         []FieldOutput `json:"fields"`
+}
+
+// FieldOutput is a field in DocOutput
+type FieldOutput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// Badges are like `deprecated`, `alpha` and `feature-gated`
+	Badges      []string `json:"badges,omitempty"`
+	Description string   `json:"description"`
+	Details     []string `json:"details,omitempty"`
+	// MergeSemantics are how server-side apply and strategic merge patch treat the field like list types
+	MergeSemantics []string `json:"mergeSemantics,omitempty"`
+	// Navigable is true if the field is an object, or an array or a map of objects, which has its own fields
+	Navigable bool `json:"navigable"`
+}
+
+// Output returns the structured doc
+func (d *Doc) Output() *DocOutput {
+	out := &DocOutput{
+		Kind:           d.gvk.Kind,
+		Group:          d.gvk.Group,
+		Version:        d.gvk.Version,
+		NewerVersion:   d.GetNewerVersion(),
+		Path:           d.GetFullPath(),
+		Type:           explain.GetTypeName(d.field),
+		Badges:         d.GetStability().Badges(),
+		Details:        d.GetDetails(),
+		MergeSemantics: d.GetMergeSemantics(),
+		Fields:         []FieldOutput{},
+	}
+	for _, desc := range d.GetDescriptions() {
+		if desc != "" {
+			out.Descriptions = append(out.Descriptions, desc)
+		}
+	}
+	if out.Descriptions == nil {
+		out.Descriptions = []string{}
+	}
+	kind := d.GetDocKind()
+	if kind == nil {
+		return out
+	}
+	for _, key := range kind.Keys() {
+		field := kind.Fields[key]
+		out.Fields = append(out.Fields, FieldOutput{
+			Name:           key,
+			Type:           explain.GetTypeName(field),
+			Required:       kind.IsRequired(key),
+			Badges:         ParseStability(field.GetDescription()).Badges(),
+			Description:    field.GetDescription(),
+			Details:        SchemaDetails(field),
+			MergeSemantics: MergeSemantics(field),
+			Navigable:      findFieldSchema(field) != nil,
+		})
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOutput(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	tests := []struct {
+		fieldsPath []string
+		want       string
+	}{
+		{
+			fieldsPath: []string{"spec"},
+			want: `{"kind":"Deployment","group":"apps","version":"v1","path":"deployment.spec","type":"Object",` +
+				`"descriptions":["Specification of the desired behavior of the Deployment."],"fields":[` +
+				`{"name":"replicas","type":"integer","required":false,"description":"Number of desired pods. Defaults to 1.","details":["Format: int32"],"navigable":false},` +
+				`{"name":"selector","type":"Object","required":true,"description":"Label selector for pods.","navigable":true},` +
+				`{"name":"template","type":"Object","required":true,"description":"Template describes the pods that will be created.","navigable":true}]}`,
+		},
+		{
+			fieldsPath: []string{"spec", "replicas"},
+			want: `{"kind":"Deployment","group":"apps","version":"v1","path":"deployment.spec.replicas","type":"integer",` +
+				`"descriptions":["Number of desired pods. Defaults to 1."],"details":["Format: int32"],"fields":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.fieldsPath, "."), func(t *testing.T) {
+			data, err := json.Marshal(newTestDoc(t, r, testDeploymentGVK, tt.fieldsPath...).Output())
+			if err != nil {
+				t.Fatalf("fail to marshal output: %v", err)
+			}
+			if got := string(data); got != tt.want {
+				t.Errorf("Output() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
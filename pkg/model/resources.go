@@ -0,0 +1,73 @@
+package model
+
+import (
+	"kexplain/pkg/gvkext"
+	"sort"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// Resources is the schema of all kinds, no matter it's from OpenAPI v2 or v3.
+// v3 documents are converted to v2 first, see NewDocumentFromV3.
+type Resources struct {
+	models proto.Models
+	// Maps gvk to model name
+	resources map[schema.GroupVersionKind]string
+}
+
+// NewResources creates Resources from an OpenAPI v2 document.
+// Validations of schemas and deprecated versions of kinds are added to the document as extensions,
+// see liftValidations and markDeprecatedVersions.
+func NewResources(doc *openapi_v2.Document) (*Resources, error) {
+	liftValidations(doc)
+	markDeprecatedVersions(doc)
+	models, err := proto.NewOpenAPIData(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[schema.GroupVersionKind]string{}
+	for _, name := range models.ListModels() {
+		for _, gvk := range gvkext.Parse(models.LookupModel(name).GetExtensions()[gvkExtKey]) {
+			resources[gvk] = name
+		}
+	}
+
+	return &Resources{
+		models:    models,
+		resources: resources,
+	}, nil
+}
+
+// LookupResource returns the schema of a kind, nil if not found
+func (r *Resources) LookupResource(gvk schema.GroupVersionKind) proto.Schema {
+	name, ok := r.resources[gvk]
+	if !ok {
+		return nil
+	}
+	return r.models.LookupModel(name)
+}
+
+// ListResources returns all kinds sorted by group, version and kind
+func (r *Resources) ListResources() []schema.GroupVersionKind {
+	gvks := make([]schema.GroupVersionKind, 0, len(r.resources))
+	for gvk := range r.resources {
+		gvks = append(gvks, gvk)
+	}
+	sortGVKs(gvks)
+	return gvks
+}
+
+func sortGVKs(gvks []schema.GroupVersionKind) {
+	sort.Slice(gvks, func(i, j int) bool {
+		if gvks[i].Group != gvks[j].Group {
+			return gvks[i].Group < gvks[j].Group
+		}
+		if gvks[i].Version != gvks[j].Version {
+			return gvks[i].Version < gvks[j].Version
+		}
+		return gvks[i].Kind < gvks[j].Kind
+	})
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"kexplain/pkg/testutil"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testDeploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+// newTestResources returns resources of a swagger fixture in testdata
+func newTestResources(t *testing.T, name string) *Resources {
+	t.Helper()
+	r, err := NewResources(testutil.Document(t, name))
+	if err != nil {
+		t.Fatalf("fail to create resources: %v", err)
+	}
+	return r
+}
+
+func newTestDoc(t *testing.T, r *Resources, gvk schema.GroupVersionKind, fieldsPath ...string) *Doc {
+	t.Helper()
+	s := r.LookupResource(gvk)
+	if s == nil {
+		t.Fatalf("not found %v", gvk)
+	}
+	doc, err := NewDoc(s, fieldsPath, gvk)
+	if err != nil {
+		t.Fatalf("fail to get doc: %v", err)
+	}
+	return doc
+}
+
+func TestListResources(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	want := []schema.GroupVersionKind{testDeploymentGVK}
+	if got := r.ListResources(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListResources() = %v, want %v", got, want)
+	}
+}
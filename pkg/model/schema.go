@@ -0,0 +1,158 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// Extensions set by kexplain for the information of OpenAPI v3, which v2 models can't hold
+const (
+	nullableExtKey = "x-kexplain-nullable"
+	oneOfExtKey    = "x-kexplain-one-of"
+	anyOfExtKey    = "x-kexplain-any-of"
+	// validations like enum and minimum, which are dropped by proto models, see liftValidations
+	validationsExtKey = "x-kexplain-validations"
+)
+
+// validationLabels are keys and labels of validations in details, in the order of showing
+var validationLabels = []struct {
+	key   string
+	label string
+}{
+	{"enum", "Enum"},
+	{"pattern", "Pattern"},
+	{"minimum", "Minimum"},
+	{"maximum", "Maximum"},
+	{"multipleOf", "Multiple of"},
+	{"minLength", "Min length"},
+	{"maxLength", "Max length"},
+	{"minItems", "Min items"},
+	{"maxItems", "Max items"},
+	{"uniqueItems", "Unique items"},
+	{"minProperties", "Min properties"},
+	{"maxProperties", "Max properties"},
+}
+
+// exclusiveKeys are flags making minimum and maximum exclusive
+var exclusiveKeys = map[string]string{
+	"minimum": "exclusiveMinimum",
+	"maximum": "exclusiveMaximum",
+}
+
+var alternativeExtKeys = map[string]string{
+	"oneOf": oneOfExtKey,
+	"anyOf": anyOfExtKey,
+}
+
+// SchemaDetails returns lines of extra information of a schema like default, nullable, enum and validations
+func SchemaDetails(s proto.Schema) []string {
+	if s == nil {
+		return nil
+	}
+	details := []string{}
+	// empty object default of refs is just the zero value
+	if def := s.GetDefault(); def != nil && !isEmptyMap(def) {
+		details = append(details, "Default: "+formatValue(def))
+	}
+	ext := s.GetExtensions()
+	if nullable, _ := ext[nullableExtKey].(bool); nullable {
+		details = append(details, "Nullable: true")
+	}
+	if types := stringList(ext[oneOfExtKey]); len(types) > 0 {
+		details = append(details, "One of: "+strings.Join(types, ", "))
+	}
+	if types := stringList(ext[anyOfExtKey]); len(types) > 0 {
+		details = append(details, "Any of: "+strings.Join(types, ", "))
+	}
+	if format := schemaFormat(s); format != "" {
+		details = append(details, "Format: "+format)
+	}
+	return append(details, validationDetails(ext[validationsExtKey])...)
+}
+
+// schemaFormat returns the format of primitives like `date-time` and `int-or-string`, including referenced ones
+func schemaFormat(s proto.Schema) string {
+	if ref, ok := s.(*proto.Ref); ok {
+		s = ref.SubSchema()
+	}
+	if p, ok := s.(*proto.Primitive); ok {
+		return p.Format
+	}
+	return ""
+}
+
+// validationDetails returns lines like `Enum: "a", "b"` and `Minimum: 1 (exclusive)`
+func validationDetails(ext interface{}) []string {
+	validations, ok := ext.(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	details := []string{}
+	for _, v := range validationLabels {
+		value, ok := validations[v.key]
+		if !ok {
+			continue
+		}
+		text := formatValue(value)
+		if pattern, ok := value.(string); ok && v.key == "pattern" {
+			// regexps are hard to read with JSON escapes
+			text = pattern
+		} else if list, ok := value.([]interface{}); ok {
+			values := make([]string, 0, len(list))
+			for _, item := range list {
+				values = append(values, formatValue(item))
+			}
+			text = strings.Join(values, ", ")
+		}
+		if exclusive, _ := validations[exclusiveKeys[v.key]].(bool); exclusive {
+			text += " (exclusive)"
+		}
+		details = append(details, v.label+": "+text)
+	}
+	return details
+}
+
+// formatValue returns JSON of values parsed from YAML extensions
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(jsonCompatible(v))
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// jsonCompatible converts map[interface{}]interface{} from YAML to map[string]interface{}
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = jsonCompatible(value)
+		}
+		return l
+	}
+	return v
+}
+
+func isEmptyMap(v interface{}) bool {
+	m, ok := v.(map[interface{}]interface{})
+	return ok && len(m) == 0
+}
+
+func stringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		result = append(result, fmt.Sprint(item))
+	}
+	return result
+}
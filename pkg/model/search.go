@@ -0,0 +1,175 @@
+package model
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	maxSearchResults = 1000
+	// runes around the match in snippets
+	snippetContext = 40
+)
+
+// SearchIndex is an index of names and descriptions of fields of resources for searching across them
+type SearchIndex struct {
+	entries []searchEntry
+}
+
+type searchEntry struct {
+	root       *Doc
+	fieldsPath []string
+	name       string
+	// lower case for matching
+	lowerName   string
+	description string
+	lowerDesc   string
+}
+
+// SearchResult is a field whose name or description contains the query
+type SearchResult struct {
+	schema.GroupVersionKind
+	// Path is the full path like `pod.spec.topologySpreadConstraints`
+	Path string
+	// Snippet is the description around the query, or its first sentence if the name matches
+	Snippet string
+
+	root       *Doc
+	fieldsPath []string
+}
+
+// Doc returns the doc of the field
+func (r *SearchResult) Doc() (*Doc, error) {
+	return r.root.FindPathDoc(r.fieldsPath)
+}
+
+// NewResourcesSearchIndex returns the index of all kinds of the resources.
+// Lists are skipped if their items are kinds, and kinds of the same model in many groups like DeleteOptions
+// are indexed once.
+func NewResourcesSearchIndex(r *Resources) *SearchIndex {
+	return NewSearchIndex(resourceRootDocs(r))
+}
+
+// resourceRootDocs returns root docs of kinds of the resources without Lists of kinds,
+// and kinds of the same model in many groups like DeleteOptions are returned once
+func resourceRootDocs(r *Resources) []*Doc {
+	docs := []*Doc{}
+	gvks := map[schema.GroupVersionKind]bool{}
+	for _, gvk := range r.ListResources() {
+		gvks[gvk] = true
+	}
+	models := map[string]bool{}
+	for _, gvk := range r.ListResources() {
+		if item := gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List")); item != gvk && gvks[item] {
+			continue
+		}
+		if models[r.resources[gvk]] {
+			continue
+		}
+		models[r.resources[gvk]] = true
+		doc, err := NewDoc(r.LookupResource(gvk), nil, gvk)
+		if err != nil {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// NewSearchIndex returns the index of fields of docs of resources
+func NewSearchIndex(roots []*Doc) *SearchIndex {
+	idx := &SearchIndex{}
+	for _, root := range roots {
+		idx.add(root, nil, strings.ToLower(root.GetKind()), root.schema.GetDescription())
+		var walk func(nodes []*FieldNode, parent []string)
+		walk = func(nodes []*FieldNode, parent []string) {
+			for _, node := range nodes {
+				path := append(append([]string{}, parent...), node.Name)
+				idx.add(root, path, node.Name, node.Description)
+				walk(node.Fields, path)
+			}
+		}
+		walk(root.GetFieldTree(), nil)
+	}
+	return idx
+}
+
+func (idx *SearchIndex) add(root *Doc, fieldsPath []string, name, description string) {
+	idx.entries = append(idx.entries, searchEntry{
+		root:        root,
+		fieldsPath:  fieldsPath,
+		name:        name,
+		lowerName:   strings.ToLower(name),
+		description: description,
+		lowerDesc:   strings.ToLower(description),
+	})
+}
+
+// Search returns fields whose names or descriptions contain the query ignoring case,
+// fields with the same name first, then ones whose names contain it, then ones whose descriptions contain it.
+func (idx *SearchIndex) Search(query string) []*SearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	// results by rank
+	ranked := [3][]*SearchResult{}
+	for i := range idx.entries {
+		e := &idx.entries[i]
+		rank := -1
+		snippet := ""
+		switch {
+		case e.lowerName == query:
+			rank, snippet = 0, firstSentence(e.description)
+		case strings.Contains(e.lowerName, query):
+			rank, snippet = 1, firstSentence(e.description)
+		default:
+			if at := strings.Index(e.lowerDesc, query); at >= 0 {
+				rank, snippet = 2, snippetAround(e.description, at, len(query))
+			}
+		}
+		if rank < 0 {
+			continue
+		}
+		ranked[rank] = append(ranked[rank], &SearchResult{
+			GroupVersionKind: e.root.gvk,
+			Path:             strings.Join(append([]string{strings.ToLower(e.root.GetKind())}, e.fieldsPath...), "."),
+			Snippet:          snippet,
+			root:             e.root,
+			fieldsPath:       e.fieldsPath,
+		})
+	}
+	results := []*SearchResult{}
+	for _, r := range ranked {
+		results = append(results, r...)
+	}
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	return results
+}
+
+// snippetAround returns the description around the match in one line
+func snippetAround(desc string, at, length int) string {
+	begin, end := at-snippetContext, at+length+snippetContext
+	prefix, suffix := "...", "..."
+	if begin <= 0 {
+		begin, prefix = 0, ""
+	}
+	if end >= len(desc) {
+		end, suffix = len(desc), ""
+	}
+	// don't cut multi-byte runes
+	for begin > 0 && !isRuneStart(desc[begin]) {
+		begin--
+	}
+	for end < len(desc) && !isRuneStart(desc[end]) {
+		end++
+	}
+	return prefix + strings.Join(strings.Fields(desc[begin:end]), " ") + suffix
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
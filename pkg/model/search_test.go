@@ -0,0 +1,73 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	idx := NewResourcesSearchIndex(newTestResources(t, "deployment.yaml"))
+	tests := []struct {
+		query        string
+		wantPaths    []string
+		wantSnippets []string
+	}{
+		{
+			// the same name first, then names containing it
+			query: "Name",
+			wantPaths: []string{
+				"deployment.metadata.name",
+				"deployment.spec.template.metadata.name",
+				"deployment.spec.template.spec.containers.name",
+				"deployment.spec.template.spec.nodeName",
+			},
+			wantSnippets: []string{"", "", "", ""},
+		},
+		{
+			query:        "desired pods",
+			wantPaths:    []string{"deployment.spec.replicas"},
+			wantSnippets: []string{"Number of desired pods. Defaults to 1."},
+		},
+		{
+			query:        "  ",
+			wantPaths:    []string{},
+			wantSnippets: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			paths, snippets := []string{}, []string{}
+			for _, r := range idx.Search(tt.query) {
+				paths = append(paths, r.Path)
+				snippets = append(snippets, r.Snippet)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) || !reflect.DeepEqual(snippets, tt.wantSnippets) {
+				t.Errorf("Search() = %q, %q, want %q, %q", paths, snippets, tt.wantPaths, tt.wantSnippets)
+			}
+		})
+	}
+}
+
+func TestSearchResultDoc(t *testing.T) {
+	idx := NewResourcesSearchIndex(newTestResources(t, "deployment.yaml"))
+	results := idx.Search("nodeName")
+	if len(results) != 1 {
+		t.Fatalf("Search() = %v, want one result", results)
+	}
+	doc, err := results[0].Doc()
+	if err != nil {
+		t.Fatalf("Doc() error = %v", err)
+	}
+	if got := doc.GetFullPath(); got != "deployment.spec.template.spec.nodeName" {
+		t.Errorf("GetFullPath() = %q, want deployment.spec.template.spec.nodeName", got)
+	}
+}
+
+func TestSnippetAround(t *testing.T) {
+	desc := "The first sentence is long enough to be cut.\nThe query is in the middle of the description, and the rest is cut too."
+	at := len("The first sentence is long enough to be cut.\nThe ")
+	want := "...sentence is long enough to be cut. The query is in the middle of the description, an..."
+	if got := snippetAround(desc, at, len("query")); got != want {
+		t.Errorf("snippetAround() = %q, want %q", got, want)
+	}
+}
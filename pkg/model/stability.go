@@ -0,0 +1,191 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// deprecatedVersionsExtKey is set by kexplain to definitions of alpha or beta versions of kinds,
+// which have newer GA versions in the same group like `batch/v1beta1` CronJob, see markDeprecatedVersions
+const deprecatedVersionsExtKey = "x-kexplain-deprecated-versions"
+
+var (
+	// `non-deprecated` isn't a deprecation
+	deprecatedRe = regexp.MustCompile(`(?i)(^|[^-\w])deprecated\b`)
+	// stability wording like `alpha feature` and `(alpha)`, but not `alpha-numeric`
+	alphaRe       = regexp.MustCompile(`(?i)\balpha[- ](?:feature|field|level|api)s?\b|\(alpha\)`)
+	featureGateRe = regexp.MustCompile(`(?i)\bfeature[- ]gates?\b`)
+	// like `requires the SuspendJob feature gate` and `the feature-gate HPAContainerMetrics`
+	featureGateNameRes = []*regexp.Regexp{
+		regexp.MustCompile("([A-Z][A-Za-z0-9]*[A-Z][A-Za-z0-9]*)[`'\"]?(?: (?:alpha|beta))? [Ff]eature[- ][Gg]ate"),
+		regexp.MustCompile("[Ff]eature[- ][Gg]ate [`'\"]?([A-Z][A-Za-z0-9]*[A-Z][A-Za-z0-9]*)"),
+	}
+)
+
+// Stability is how stable a field is, which is parsed from its description
+type Stability struct {
+	Deprecated bool
+	Alpha      bool
+	// FeatureGated is true if the field requires a feature gate, even when its name is unknown
+	FeatureGated bool
+	// FeatureGates are names of feature gates the field requires like `SuspendJob`
+	FeatureGates []string
+}
+
+// ParseStability returns the stability written in a description like "Deprecated" and "This is an alpha field"
+func ParseStability(description string) *Stability {
+	s := &Stability{
+		Deprecated:   deprecatedRe.MatchString(description),
+		Alpha:        alphaRe.MatchString(description),
+		FeatureGated: featureGateRe.MatchString(description),
+	}
+	seen := map[string]bool{}
+	for _, re := range featureGateNameRes {
+		for _, match := range re.FindAllStringSubmatch(description, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				s.FeatureGates = append(s.FeatureGates, match[1])
+			}
+		}
+	}
+	return s
+}
+
+// Unstable returns true if the field is deprecated or alpha, which can be removed in later versions
+func (s *Stability) Unstable() bool {
+	return s.Deprecated || s.Alpha
+}
+
+// Badges returns marks like `deprecated` and `alpha`
+func (s *Stability) Badges() []string {
+	badges := []string{}
+	if s.Deprecated {
+		badges = append(badges, "deprecated")
+	}
+	if s.Alpha {
+		badges = append(badges, "alpha")
+	}
+	if s.FeatureGated {
+		badges = append(badges, "feature-gated")
+	}
+	return badges
+}
+
+// GetStability returns the stability of the field, or of the kind for the root doc
+func (d *Doc) GetStability() *Stability {
+	if d.fieldName == "" {
+		return ParseStability(strings.Join(d.GetDescriptions(), "\n"))
+	}
+	return ParseStability(d.field.GetDescription())
+}
+
+// GetNewerVersion returns the GA group version like `batch/v1` deprecating the one of the doc,
+// or empty if the version of the doc is GA or there's no newer GA version
+func (d *Doc) GetNewerVersion() string {
+	list, _ := d.schema.GetExtensions()[deprecatedVersionsExtKey].([]interface{})
+	for _, item := range list {
+		m, ok := item.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		if m["group"] == d.gvk.Group && m["version"] == d.gvk.Version && m["kind"] == d.gvk.Kind {
+			newer, _ := m["newer"].(string)
+			return newer
+		}
+	}
+	return ""
+}
+
+// markDeprecatedVersions adds the newest GA version of the kind in the same group to definitions of alpha or beta
+// versions of it, like `batch/v1` to `batch/v1beta1` CronJob. GA versions of older major versions don't deprecate
+// them like `autoscaling/v1` for `autoscaling/v2beta2`, and moves between groups are only in descriptions.
+func markDeprecatedVersions(doc *openapi_v2.Document) {
+	type gvkEntry struct {
+		Group   string `yaml:"group"`
+		Version string `yaml:"version"`
+		Kind    string `yaml:"kind"`
+	}
+	definitionGVKs := map[*openapi_v2.Schema][]schema.GroupVersionKind{}
+	kindVersions := map[schema.GroupKind][]string{}
+	for _, named := range doc.GetDefinitions().GetAdditionalProperties() {
+		s := named.GetValue()
+		for _, ext := range s.GetVendorExtension() {
+			if ext.GetName() != gvkExtKey {
+				continue
+			}
+			var entries []gvkEntry
+			if err := yaml.Unmarshal([]byte(ext.GetValue().GetYaml()), &entries); err != nil {
+				continue
+			}
+			for _, e := range entries {
+				if e.Version == "" || e.Kind == "" {
+					continue
+				}
+				gvk := schema.GroupVersionKind{Group: e.Group, Version: e.Version, Kind: e.Kind}
+				definitionGVKs[s] = append(definitionGVKs[s], gvk)
+				kindVersions[gvk.GroupKind()] = append(kindVersions[gvk.GroupKind()], gvk.Version)
+			}
+		}
+	}
+
+	for s, gvks := range definitionGVKs {
+		deprecated := []map[string]string{}
+		for _, gvk := range gvks {
+			if !isPreReleaseVersion(gvk.Version) {
+				continue
+			}
+			newest := ""
+			for _, v := range kindVersions[gvk.GroupKind()] {
+				if isPreReleaseVersion(v) || majorVersion(v) < majorVersion(gvk.Version) {
+					continue
+				}
+				if newest == "" || version.CompareKubeAwareVersionStrings(v, newest) > 0 {
+					newest = v
+				}
+			}
+			if newest == "" {
+				continue
+			}
+			deprecated = append(deprecated, map[string]string{
+				"group":   gvk.Group,
+				"version": gvk.Version,
+				"kind":    gvk.Kind,
+				"newer":   schema.GroupVersion{Group: gvk.Group, Version: newest}.String(),
+			})
+		}
+		if len(deprecated) == 0 {
+			continue
+		}
+		data, err := yaml.Marshal(deprecated)
+		if err != nil {
+			continue
+		}
+		s.VendorExtension = append(s.VendorExtension, &openapi_v2.NamedAny{
+			Name:  deprecatedVersionsExtKey,
+			Value: &openapi_v2.Any{Yaml: string(data)},
+		})
+	}
+}
+
+var majorVersionRe = regexp.MustCompile(`^v(\d+)`)
+
+// majorVersion returns 2 for `v2beta2`, or 0 if it's not a k8s version
+func majorVersion(v string) int {
+	match := majorVersionRe.FindStringSubmatch(v)
+	if match == nil {
+		return 0
+	}
+	major, _ := strconv.Atoi(match[1])
+	return major
+}
+
+// isPreReleaseVersion returns true for alpha and beta versions like `v1beta1`
+func isPreReleaseVersion(v string) bool {
+	return strings.Contains(v, "alpha") || strings.Contains(v, "beta")
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseStability(t *testing.T) {
+	tests := []struct {
+		description string
+		want        *Stability
+	}{
+		{
+			description: "Deprecated: use spec.ingressClassName instead.",
+			want:        &Stability{Deprecated: true},
+		},
+		{
+			description: "This is a non-deprecated field.",
+			want:        &Stability{},
+		},
+		{
+			description: "This is an alpha field and requires enabling the ProbeTerminationGracePeriod feature gate.",
+			want:        &Stability{Alpha: true, FeatureGated: true, FeatureGates: []string{"ProbeTerminationGracePeriod"}},
+		},
+		{
+			description: "This field is beta-level and is only honored when the feature-gate HPAContainerMetrics is enabled.",
+			want:        &Stability{FeatureGated: true, FeatureGates: []string{"HPAContainerMetrics"}},
+		},
+		{
+			description: "(Alpha) Using this field requires the ReadWriteOncePod feature gate to be enabled.",
+			want:        &Stability{Alpha: true, FeatureGated: true, FeatureGates: []string{"ReadWriteOncePod"}},
+		},
+		{
+			description: "This field is alpha-level and is only honored by servers that enable the feature.",
+			want:        &Stability{Alpha: true},
+		},
+		{
+			description: "Name must consist of alpha-numeric characters or '-'.",
+			want:        &Stability{},
+		},
+		{
+			description: "Keys are alpha characters, like the letters alpha and beta.",
+			want:        &Stability{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := ParseStability(tt.description); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStability() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkDeprecatedVersions(t *testing.T) {
+	gvks := []schema.GroupVersionKind{
+		{Group: "autoscaling", Version: "v1", Kind: "HorizontalPodAutoscaler"},
+		{Group: "autoscaling", Version: "v2beta1", Kind: "HorizontalPodAutoscaler"},
+		{Group: "autoscaling", Version: "v2beta2", Kind: "HorizontalPodAutoscaler"},
+		{Group: "batch", Version: "v1", Kind: "CronJob"},
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+		{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+		{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Kind: "FlowSchema"},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Kind: "FlowSchema"},
+		{Group: "storage.k8s.io", Version: "v1alpha1", Kind: "CSIStorageCapacity"},
+		{Group: "storage.k8s.io", Version: "v1beta1", Kind: "CSIStorageCapacity"},
+		{Group: "storage.k8s.io", Version: "v1", Kind: "CSIStorageCapacity"},
+		{Group: "example.io", Version: "v1", Kind: "Widget"},
+		{Group: "example.io", Version: "v2beta1", Kind: "Widget"},
+		{Group: "example.io", Version: "v2", Kind: "Widget"},
+	}
+	r := newTestResources(t, "versions.yaml")
+	tests := []struct {
+		gvk  schema.GroupVersionKind
+		want string
+	}{
+		{gvk: gvks[0], want: ""},
+		// v1 is older than v2 betas
+		{gvk: gvks[1], want: ""},
+		{gvk: gvks[2], want: ""},
+		{gvk: gvks[3], want: ""},
+		{gvk: gvks[4], want: "batch/v1"},
+		// moves between groups are only in descriptions
+		{gvk: gvks[5], want: ""},
+		{gvk: gvks[6], want: ""},
+		// no GA version
+		{gvk: gvks[7], want: ""},
+		{gvk: gvks[8], want: ""},
+		{gvk: gvks[9], want: "storage.k8s.io/v1"},
+		{gvk: gvks[10], want: "storage.k8s.io/v1"},
+		{gvk: gvks[11], want: ""},
+		// v2 deprecates the betas
+		{gvk: gvks[12], want: ""},
+		{gvk: gvks[13], want: "example.io/v2"},
+		{gvk: gvks[14], want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gvk.String(), func(t *testing.T) {
+			doc := newTestDoc(t, r, tt.gvk)
+			if got := doc.GetNewerVersion(); got != tt.want {
+				t.Errorf("GetNewerVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
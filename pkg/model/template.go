@@ -0,0 +1,199 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// DefaultTemplateDepth is the default depth of objects expanded in a template
+const DefaultTemplateDepth = 5
+
+const (
+	templateIndent = "  "
+	// max length of descriptions in comments
+	templateCommentLen = 80
+)
+
+// Template returns a YAML skeleton of the doc. Required fields are filled with placeholders of their types,
+// and optional fields are commented out with the first sentence of their descriptions.
+// Optional objects having required fields inside are filled too when their parents have no required fields,
+// like the pod spec of a deployment.
+// Objects deeper than maxDepth or of the same type as their parents are not expanded.
+func (d *Doc) Template(maxDepth int) string {
+	t := &templateWriter{maxDepth: maxDepth, parents: map[*proto.Kind]bool{}}
+	switch s := resolveRef(d.field).(type) {
+	case *proto.Kind:
+		t.parents[s] = true
+		skip := map[string]bool{}
+		if len(d.fieldsPath) == 0 {
+			// a resource needs these to be applied
+			gv := d.gvk.GroupVersion().String()
+			t.line("", "apiVersion: "+gv)
+			t.line("", "kind: "+d.gvk.Kind)
+			t.line("", "metadata:")
+			t.line(templateIndent, `name: ""`)
+			skip = map[string]bool{"apiVersion": true, "kind": true, "metadata": true, "spec": true}
+			// spec is optional in most schemas, but is what users write
+			if spec, ok := s.Fields["spec"]; ok {
+				t.writeField("spec", spec, "", 1)
+			}
+		}
+		t.writeFields(s, "", 1, skip)
+	case *proto.Array:
+		t.writeItem(s.SubType, "", 1)
+	default:
+		t.line("", placeholder(s))
+	}
+	return t.b.String()
+}
+
+type templateWriter struct {
+	b        strings.Builder
+	maxDepth int
+	// kinds being expanded
+	parents map[*proto.Kind]bool
+}
+
+// the end of a sentence, but not abbreviations like `e.g.`
+var sentenceEnd = regexp.MustCompile(`\.\s+[A-Z]`)
+
+func (t *templateWriter) line(indent, text string) {
+	t.b.WriteString(indent + text + "\n")
+}
+
+func (t *templateWriter) writeFields(kind *proto.Kind, indent string, depth int, skip map[string]bool) {
+	// a parent without required fields would be empty, like the pod template of a deployment
+	fillOptional := len(kind.RequiredFields) == 0
+	for _, key := range kind.Keys() {
+		if skip[key] {
+			continue
+		}
+		field := kind.Fields[key]
+		if !kind.IsRequired(key) && !(fillOptional && t.hasRequired(field, depth)) {
+			t.line(indent, "# "+key+": "+placeholder(field)+templateComment(field))
+			continue
+		}
+		t.writeField(key, field, indent, depth)
+	}
+}
+
+func (t *templateWriter) writeField(key string, field proto.Schema, indent string, depth int) {
+	switch s := resolveRef(field).(type) {
+	case *proto.Kind:
+		if !t.expandable(s, depth) {
+			break
+		}
+		// no `{}` even if all fields inside are commented out, which would be invalid after uncommenting one
+		t.line(indent, key+":"+templateComment(field))
+		t.parents[s] = true
+		t.writeFields(s, indent+templateIndent, depth+1, nil)
+		delete(t.parents, s)
+		return
+	case *proto.Array:
+		if item, ok := resolveRef(s.SubType).(*proto.Kind); ok && t.expandable(item, depth) {
+			t.line(indent, key+":"+templateComment(field))
+			t.writeItem(item, indent, depth+1)
+			return
+		}
+	}
+	t.line(indent, key+": "+placeholder(field)+templateComment(field))
+}
+
+// writeItem writes an item of an array
+func (t *templateWriter) writeItem(item proto.Schema, indent string, depth int) {
+	kind, ok := resolveRef(item).(*proto.Kind)
+	if !ok || !t.expandable(kind, depth) {
+		t.line(indent, "- "+placeholder(item))
+		return
+	}
+	t.parents[kind] = true
+	fields := t.fields(kind, indent+templateIndent, depth, nil)
+	delete(t.parents, kind)
+
+	lines := strings.SplitAfter(fields, "\n")
+	first := strings.TrimPrefix(lines[0], indent+templateIndent)
+	if strings.HasPrefix(first, "#") {
+		// a comment can't be the first line of an item
+		t.line(indent, "-")
+	} else {
+		lines[0] = indent + "- " + first
+	}
+	t.b.WriteString(strings.Join(lines, ""))
+}
+
+// fields returns lines of the fields of the kind
+func (t *templateWriter) fields(kind *proto.Kind, indent string, depth int, skip map[string]bool) string {
+	sub := &templateWriter{maxDepth: t.maxDepth, parents: t.parents}
+	sub.writeFields(kind, indent, depth, skip)
+	return sub.b.String()
+}
+
+// hasRequired returns whether the field is an object which is expanded with required fields inside.
+// Items of arrays are not checked, because they are required only when the array has any.
+func (t *templateWriter) hasRequired(field proto.Schema, depth int) bool {
+	kind, ok := resolveRef(field).(*proto.Kind)
+	if !ok || !t.expandable(kind, depth) {
+		return false
+	}
+	t.parents[kind] = true
+	defer delete(t.parents, kind)
+	for _, key := range kind.Keys() {
+		if kind.IsRequired(key) || t.hasRequired(kind.Fields[key], depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *templateWriter) expandable(kind *proto.Kind, depth int) bool {
+	return depth < t.maxDepth && !t.parents[kind] && len(kind.Fields) > 0
+}
+
+// placeholder returns a YAML value for the type of the schema
+func placeholder(s proto.Schema) string {
+	switch s := resolveRef(s).(type) {
+	case *proto.Primitive:
+		switch s.Type {
+		case "integer", "number":
+			return "0"
+		case "boolean":
+			return "false"
+		}
+		return `""`
+	case *proto.Array:
+		return "[]"
+	case *proto.Map, *proto.Kind, *proto.Arbitrary:
+		return "{}"
+	}
+	return `""`
+}
+
+// templateComment returns the first sentence of the description as a comment
+func templateComment(s proto.Schema) string {
+	desc := firstSentence(s.GetDescription())
+	if desc == "" {
+		return ""
+	}
+	if runes := []rune(desc); len(runes) > templateCommentLen {
+		desc = string(runes[:templateCommentLen-3]) + "..."
+	}
+	return "  # " + desc
+}
+
+// firstSentence returns the first sentence of the description in one line
+func firstSentence(desc string) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if loc := sentenceEnd.FindStringIndex(desc); loc != nil {
+		desc = desc[:loc[0]+1]
+	}
+	return desc
+}
+
+func resolveRef(s proto.Schema) proto.Schema {
+	if ref, ok := s.(*proto.Ref); ok {
+		return ref.SubSchema()
+	}
+	return s
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTemplate(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	tests := []struct {
+		name       string
+		fieldsPath []string
+		depth      int
+		want       string
+	}{
+		{
+			name:  "resource",
+			depth: 6,
+			want: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ""
+spec:  # Specification of the desired behavior of the Deployment.
+  # replicas: 0  # Number of desired pods.
+  selector:  # Label selector for pods.
+    # matchLabels: {}  # matchLabels is a map of {key,value} pairs.
+  template:  # Template describes the pods that will be created.
+    # metadata: {}
+    spec:
+      containers:
+      -
+        # image: ""
+        # livenessProbe: {}
+        name: ""
+        # ports: []
+        # resources: {}
+        # stdin: false
+      # nodeName: ""
+`,
+		},
+		{
+			name:  "shallow",
+			depth: 3,
+			want: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ""
+spec:  # Specification of the desired behavior of the Deployment.
+  # replicas: 0  # Number of desired pods.
+  selector:  # Label selector for pods.
+    # matchLabels: {}  # matchLabels is a map of {key,value} pairs.
+  template:  # Template describes the pods that will be created.
+    # metadata: {}
+    # spec: {}
+`,
+		},
+		{
+			name:       "field with only optional fields",
+			fieldsPath: []string{"spec", "selector"},
+			depth:      DefaultTemplateDepth,
+			want: `# matchLabels: {}  # matchLabels is a map of {key,value} pairs.
+`,
+		},
+		{
+			name:       "array",
+			fieldsPath: []string{"spec", "template", "spec", "containers", "ports"},
+			depth:      DefaultTemplateDepth,
+			want: `- containerPort: 0
+`,
+		},
+		{
+			name:       "primitive",
+			fieldsPath: []string{"spec", "replicas"},
+			depth:      DefaultTemplateDepth,
+			want: `0
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newTestDoc(t, r, testDeploymentGVK, tt.fieldsPath...)
+			if got := doc.Template(tt.depth); got != tt.want {
+				t.Errorf("Template() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateUncommented(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	lines := strings.Split(newTestDoc(t, r, testDeploymentGVK).Template(DefaultTemplateDepth), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+		uncommented := append([]string{}, lines...)
+		uncommented[i] = line[:len(line)-len(trimmed)] + strings.TrimPrefix(trimmed, "# ")
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(strings.Join(uncommented, "\n")), &obj); err != nil {
+			t.Errorf("fail to parse the template with %q uncommented: %v", trimmed, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/kubectl/pkg/explain"
+)
+
+// FieldNode is a field in the field tree of a doc
+type FieldNode struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+	// Recursive is true when the field is of the same type as one of its parents, like JSONSchemaProps,
+	// whose fields are not expanded again
+	Recursive bool
+	Fields    []*FieldNode
+}
+
+// GetFieldTree returns fields of the doc and their fields recursively through refs, arrays and maps
+func (d *Doc) GetFieldTree() []*FieldNode {
+	kind := d.GetDocKind()
+	if kind == nil {
+		return nil
+	}
+	return fieldNodes(kind, map[*proto.Kind]bool{kind: true})
+}
+
+// GetFieldPaths returns paths of all fields of the doc and their fields relative to the doc,
+// like `["containers", "name"]` for `pod.spec`. Fields of recursive types are not walked again.
+func (d *Doc) GetFieldPaths() [][]string {
+	paths := [][]string{}
+	var walk func(nodes []*FieldNode, parent []string)
+	walk = func(nodes []*FieldNode, parent []string) {
+		for _, node := range nodes {
+			path := append(append([]string{}, parent...), node.Name)
+			paths = append(paths, path)
+			walk(node.Fields, path)
+		}
+	}
+	walk(d.GetFieldTree(), nil)
+	return paths
+}
+
+// fieldNodes returns nodes of fields of kind, parents are kinds which are being expanded
+func fieldNodes(kind *proto.Kind, parents map[*proto.Kind]bool) []*FieldNode {
+	nodes := make([]*FieldNode, 0, len(kind.Fields))
+	for _, key := range kind.Keys() {
+		field := kind.Fields[key]
+		node := &FieldNode{
+			Name:        key,
+			Type:        explain.GetTypeName(field),
+			Required:    kind.IsRequired(key),
+			Description: field.GetDescription(),
+		}
+		if sub, ok := elemSchema(field).(*proto.Kind); ok {
+			if parents[sub] {
+				node.Recursive = true
+			} else {
+				parents[sub] = true
+				node.Fields = fieldNodes(sub, parents)
+				delete(parents, sub)
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// elemSchema returns the schema of elements of arrays and maps, or the schema of refs
+func elemSchema(s proto.Schema) proto.Schema {
+	switch t := s.(type) {
+	case *proto.Array:
+		return elemSchema(t.SubType)
+	case *proto.Map:
+		return elemSchema(t.SubType)
+	case *proto.Ref:
+		return t.SubSchema()
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetFieldTree(t *testing.T) {
+	r := newTestResources(t, "recursive.yaml")
+	doc := newTestDoc(t, r, schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Tree"})
+	want := []*FieldNode{
+		{Name: "root", Type: "Object", Fields: []*FieldNode{
+			{Name: "children", Type: "[]Object", Recursive: true},
+			{Name: "name", Type: "string", Required: true},
+		}},
+	}
+	if got := doc.GetFieldTree(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFieldTree() = %s, want %s", formatFieldNodes(got), formatFieldNodes(want))
+	}
+}
+
+func TestGetFieldTreeThroughArraysAndMaps(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	nodes := newTestDoc(t, r, testDeploymentGVK, "spec", "template", "spec").GetFieldTree()
+	if len(nodes) == 0 || nodes[0].Name != "containers" {
+		t.Fatalf("GetFieldTree() = %s, want containers first", formatFieldNodes(nodes))
+	}
+	names := []string{}
+	for _, n := range nodes[0].Fields {
+		names = append(names, n.Name)
+	}
+	want := []string{"image", "livenessProbe", "name", "ports", "resources", "stdin"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("fields of containers = %v, want %v", names, want)
+	}
+}
+
+func formatFieldNodes(nodes []*FieldNode) string {
+	s := "["
+	for i, n := range nodes {
+		if i > 0 {
+			s += " "
+		}
+		s += n.Name
+		if len(n.Fields) > 0 {
+			s += formatFieldNodes(n.Fields)
+		}
+	}
+	return s + "]"
+}
+
+func TestGetFieldPaths(t *testing.T) {
+	r := newTestResources(t, "recursive.yaml")
+	doc := newTestDoc(t, r, schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Tree"})
+	want := [][]string{{"root"}, {"root", "children"}, {"root", "name"}}
+	if got := doc.GetFieldPaths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFieldPaths() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const maxUsedByResults = 1000
+
+// UsedByIndex is a reverse index of definitions, which finds every kind and path embedding a type like PodSpec
+type UsedByIndex struct {
+	// root docs of kinds sorted by kinds and group versions
+	roots []*Doc
+	// names of models having fields of the model by model names
+	referrers map[string][]string
+	// models whose fields are indexed
+	models map[string]bool
+	// maxResults is the number of fields returned by UsedBy at most
+	maxResults int
+}
+
+// UsedByResult is a field whose type is the one searched in UsedByIndex
+type UsedByResult struct {
+	schema.GroupVersionKind
+	// Path is the full path like `deployment.spec.template.spec`
+	Path string
+
+	root       *Doc
+	fieldsPath []string
+}
+
+// Doc returns the doc of the field
+func (r *UsedByResult) Doc() (*Doc, error) {
+	return r.root.FindPathDoc(r.fieldsPath)
+}
+
+// NewResourcesUsedByIndex returns the index of all kinds of the resources.
+// Lists and kinds of the same model in many groups are skipped like NewResourcesSearchIndex.
+func NewResourcesUsedByIndex(r *Resources) *UsedByIndex {
+	return NewUsedByIndex(resourceRootDocs(r))
+}
+
+// NewUsedByIndex returns the index of definitions referenced from the root docs of kinds
+func NewUsedByIndex(roots []*Doc) *UsedByIndex {
+	idx := &UsedByIndex{
+		roots:      append([]*Doc{}, roots...),
+		referrers:  map[string][]string{},
+		models:     map[string]bool{},
+		maxResults: maxUsedByResults,
+	}
+	sort.SliceStable(idx.roots, func(i, j int) bool {
+		ki, kj := strings.ToLower(idx.roots[i].gvk.Kind), strings.ToLower(idx.roots[j].gvk.Kind)
+		if ki != kj {
+			return ki < kj
+		}
+		return idx.roots[i].gvk.GroupVersion().String() < idx.roots[j].gvk.GroupVersion().String()
+	})
+	for _, root := range idx.roots {
+		idx.addModel(root.GetModelName(), root.schema)
+	}
+	return idx
+}
+
+// addModel adds the model as a referrer of models of its fields, and the referenced models
+func (idx *UsedByIndex) addModel(name string, s proto.Schema) {
+	if idx.models[name] {
+		return
+	}
+	idx.models[name] = true
+	var walk func(s proto.Schema)
+	walk = func(s proto.Schema) {
+		switch t := s.(type) {
+		case *proto.Ref:
+			ref := t.Reference()
+			idx.referrers[ref] = append(idx.referrers[ref], name)
+			idx.addModel(ref, t.SubSchema())
+		case *proto.Array:
+			walk(t.SubType)
+		case *proto.Map:
+			walk(t.SubType)
+		case *proto.Kind:
+			for _, key := range t.Keys() {
+				walk(t.Fields[key])
+			}
+		}
+	}
+	walk(s)
+}
+
+// UsedBy returns fields of kinds whose type is the one of the doc, and the number of all fields.
+// Fields are walked from kinds down to the type in the order of kinds and their fields, so only the first
+// maxResults fields are kept and the rest are only counted. Paths through recursive types like JSONSchemaProps
+// are included only once.
+func (idx *UsedByIndex) UsedBy(doc *Doc) ([]*UsedByResult, int) {
+	name := doc.GetModelName()
+	if name == "" {
+		return nil, 0
+	}
+	reaching := idx.modelsReaching(name)
+	results := []*UsedByResult{}
+	total := 0
+	visiting := map[string]bool{}
+	var root *Doc
+	// fieldsPath is shared in the walk, which is copied only for results
+	var fieldsPath []string
+	var walk func(s proto.Schema)
+	walk = func(s proto.Schema) {
+		switch t := s.(type) {
+		case *proto.Ref:
+			ref := t.Reference()
+			if ref == name {
+				total++
+				if len(results) < idx.maxResults {
+					results = append(results, &UsedByResult{
+						GroupVersionKind: root.gvk,
+						Path:             strings.Join(append([]string{strings.ToLower(root.gvk.Kind)}, fieldsPath...), "."),
+						root:             root,
+						fieldsPath:       append([]string{}, fieldsPath...),
+					})
+				}
+			}
+			if !reaching[ref] || visiting[ref] {
+				return
+			}
+			visiting[ref] = true
+			walk(t.SubSchema())
+			delete(visiting, ref)
+		case *proto.Array:
+			walk(t.SubType)
+		case *proto.Map:
+			walk(t.SubType)
+		case *proto.Kind:
+			for _, key := range t.Keys() {
+				fieldsPath = append(fieldsPath, key)
+				walk(t.Fields[key])
+				fieldsPath = fieldsPath[:len(fieldsPath)-1]
+			}
+		}
+	}
+	for _, root = range idx.roots {
+		rootName := root.GetModelName()
+		if !reaching[rootName] {
+			continue
+		}
+		visiting[rootName] = true
+		walk(root.schema)
+		delete(visiting, rootName)
+	}
+	return results, total
+}
+
+// modelsReaching returns names of models having fields of the model, directly or through other models.
+// The model itself is included if it's recursive.
+func (idx *UsedByIndex) modelsReaching(name string) map[string]bool {
+	reaching := map[string]bool{}
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, from := range idx.referrers[current] {
+			if !reaching[from] {
+				reaching[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+	return reaching
+}
+
+// GetModelName returns the name of the definition of the type of the doc like `io.k8s.api.core.v1.PodSpec`,
+// or empty for inline objects and primitives
+func (d *Doc) GetModelName() string {
+	if len(d.fieldsPath) == 0 {
+		return d.schema.GetPath().String()
+	}
+	field := d.field
+	for unwrapped := true; unwrapped; {
+		switch t := field.(type) {
+		case *proto.Array:
+			field = t.SubType
+		case *proto.Map:
+			field = t.SubType
+		default:
+			unwrapped = false
+		}
+	}
+	if ref, ok := field.(*proto.Ref); ok {
+		return ref.Reference()
+	}
+	return ""
+}
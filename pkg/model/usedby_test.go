@@ -0,0 +1,75 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestUsedBy(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	tests := []struct {
+		name       string
+		fieldsPath []string
+		want       []string
+	}{
+		{
+			name:       "object",
+			fieldsPath: []string{"metadata"},
+			want:       []string{"deployment.metadata", "deployment.spec.template.metadata"},
+		},
+		{
+			name:       "item of array",
+			fieldsPath: []string{"spec", "template", "spec", "containers"},
+			want:       []string{"deployment.spec.template.spec.containers"},
+		},
+		{
+			name:       "map",
+			fieldsPath: []string{"spec", "template", "spec", "containers", "resources", "limits"},
+			want:       []string{"deployment.spec.template.spec.containers.resources.limits"},
+		},
+		{
+			name:       "primitive",
+			fieldsPath: []string{"spec", "replicas"},
+			want:       []string{},
+		},
+	}
+	idx := NewResourcesUsedByIndex(r)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total := idx.UsedBy(newTestDoc(t, r, testDeploymentGVK, tt.fieldsPath...))
+			got := []string{}
+			for _, result := range results {
+				got = append(got, result.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) || total != len(tt.want) {
+				t.Errorf("UsedBy() = %v, %d, want %v", got, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsedByTruncated(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	idx := NewResourcesUsedByIndex(r)
+	idx.maxResults = 1
+	results, total := idx.UsedBy(newTestDoc(t, r, testDeploymentGVK, "metadata"))
+	if len(results) != 1 || results[0].Path != "deployment.metadata" || total != 2 {
+		t.Errorf("UsedBy() returns %d of %d, want deployment.metadata of 2", len(results), total)
+	}
+}
+
+func TestUsedByRecursiveType(t *testing.T) {
+	r := newTestResources(t, "recursive.yaml")
+	gvk := schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Tree"}
+	results, total := NewResourcesUsedByIndex(r).UsedBy(newTestDoc(t, r, gvk, "root"))
+	got := []string{}
+	for _, result := range results {
+		got = append(got, result.Path)
+	}
+	want := []string{"tree.root", "tree.root.children"}
+	if !reflect.DeepEqual(got, want) || total != len(want) {
+		t.Errorf("UsedBy() = %v, %d, want %v", got, total, want)
+	}
+}
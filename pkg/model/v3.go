@@ -0,0 +1,226 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	v3RefPrefix = "#/components/schemas/"
+	v2RefPrefix = "#/definitions/"
+
+	intOrStringExtKey = "x-kubernetes-int-or-string"
+)
+
+// Keys v2 schema allows besides extensions. Others are v3 only and are converted or dropped.
+var v2SchemaKeys = map[string]bool{
+	"$ref": true, "additionalProperties": true, "allOf": true, "default": true, "description": true,
+	"discriminator": true, "enum": true, "example": true, "exclusiveMaximum": true, "exclusiveMinimum": true,
+	"externalDocs": true, "format": true, "items": true, "maxItems": true, "maxLength": true,
+	"maxProperties": true, "maximum": true, "minItems": true, "minLength": true, "minProperties": true,
+	"minimum": true, "multipleOf": true, "pattern": true, "properties": true, "readOnly": true,
+	"required": true, "title": true, "type": true, "uniqueItems": true, "xml": true,
+}
+
+// IsV3 returns true if the JSON or YAML data is an OpenAPI v3 document
+func IsV3(data []byte) bool {
+	var head struct {
+		OpenAPI string `json:"openapi"`
+	}
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(jsonData, &head); err != nil {
+		return false
+	}
+	return strings.HasPrefix(head.OpenAPI, "3.")
+}
+
+// NewDocumentFromV3 converts OpenAPI v3 documents, like ones of every group version from `/openapi/v3`,
+// to one v2 document. Information v2 can't hold like nullable and oneOf is kept in extensions, see SchemaDetails.
+func NewDocumentFromV3(specs ...[]byte) (*openapi_v2.Document, error) {
+	schemas := map[string]map[string]interface{}{}
+	paths := map[string]interface{}{}
+	version := ""
+	for _, spec := range specs {
+		jsonData, err := yaml.ToJSON(spec)
+		if err != nil {
+			return nil, err
+		}
+		var doc struct {
+			Info struct {
+				Version string `json:"version"`
+			} `json:"info"`
+			Paths      map[string]map[string]interface{} `json:"paths"`
+			Components struct {
+				Schemas map[string]map[string]interface{} `json:"schemas"`
+			} `json:"components"`
+		}
+		if err := json.Unmarshal(jsonData, &doc); err != nil {
+			return nil, fmt.Errorf("fail to parse OpenAPI v3 document: %w", err)
+		}
+		if version == "" {
+			version = doc.Info.Version
+		}
+		for name, s := range doc.Components.Schemas {
+			schemas[name] = s
+		}
+		for name, p := range doc.Paths {
+			paths[name] = convertV3Path(p)
+		}
+	}
+	return newDocumentFromV3Schemas(version, paths, schemas)
+}
+
+func newDocumentFromV3Schemas(version string, paths map[string]interface{}, schemas map[string]map[string]interface{}) (*openapi_v2.Document, error) {
+	definitions := make(map[string]interface{}, len(schemas))
+	for name, s := range schemas {
+		definitions[name] = convertV3Schema(s)
+	}
+	v2 := map[string]interface{}{
+		"swagger":     "2.0",
+		"info":        map[string]interface{}{"title": "Kubernetes", "version": version},
+		"paths":       paths,
+		"definitions": definitions,
+	}
+	data, err := json.Marshal(v2)
+	if err != nil {
+		return nil, err
+	}
+	return openapi_v2.ParseDocument(data)
+}
+
+// convertV3Path only keeps extensions of operations, which tell kinds and resources of paths
+func convertV3Path(p map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, method := range []string{"get", "put", "post", "delete", "patch"} {
+		op, ok := p[method].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		newOp := map[string]interface{}{
+			"responses": map[string]interface{}{"default": map[string]interface{}{"description": ""}},
+		}
+		for key, v := range op {
+			if strings.HasPrefix(key, "x-") {
+				newOp[key] = v
+			}
+		}
+		result[method] = newOp
+	}
+	return result
+}
+
+func convertV3Schema(s map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+
+	// k8s wraps refs in allOf to add description or default
+	if allOf, ok := s["allOf"].([]interface{}); ok && len(allOf) == 1 {
+		if sub, ok := allOf[0].(map[string]interface{}); ok {
+			if ref, ok := sub["$ref"]; ok {
+				s["$ref"] = ref
+				delete(s, "allOf")
+			}
+		}
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		// v2 doesn't allow anything else along with a ref
+		result["$ref"] = v2RefPrefix + strings.TrimPrefix(ref, v3RefPrefix)
+		for _, key := range []string{"description", "default"} {
+			if v, ok := s[key]; ok {
+				result[key] = v
+			}
+		}
+		if nullable, ok := s["nullable"]; ok {
+			result[nullableExtKey] = nullable
+		}
+		return result
+	}
+
+	for key, v := range s {
+		switch {
+		case key == "properties":
+			props := map[string]interface{}{}
+			if m, ok := v.(map[string]interface{}); ok {
+				for name, prop := range m {
+					if propSchema, ok := prop.(map[string]interface{}); ok {
+						props[name] = convertV3Schema(propSchema)
+					}
+				}
+			}
+			result[key] = props
+		case key == "items" || key == "additionalProperties":
+			if sub, ok := v.(map[string]interface{}); ok {
+				result[key] = convertV3Schema(sub)
+			} else {
+				result[key] = v
+			}
+		case key == "allOf":
+			if list, ok := v.([]interface{}); ok {
+				subs := make([]interface{}, 0, len(list))
+				for _, item := range list {
+					if sub, ok := item.(map[string]interface{}); ok {
+						subs = append(subs, convertV3Schema(sub))
+					}
+				}
+				result[key] = subs
+			}
+		case key == "nullable":
+			result[nullableExtKey] = v
+		case key == "oneOf" || key == "anyOf":
+			result[alternativeExtKeys[key]] = alternativeTypes(v)
+		case v2SchemaKeys[key] || strings.HasPrefix(key, "x-"):
+			result[key] = v
+		}
+	}
+
+	// validations are kept with the presence, which is lost in v2 models like `minimum: 0`
+	if validations := v3Validations(s); validations != nil {
+		result[validationsExtKey] = validations
+	}
+	// int-or-string has no type in v3, but v2 uses string with the format
+	if intOrString, _ := s[intOrStringExtKey].(bool); intOrString {
+		if _, ok := result["type"]; !ok {
+			result["type"] = "string"
+			result["format"] = "int-or-string"
+		}
+		delete(result, alternativeExtKeys["anyOf"])
+	}
+	// v2 models reject an array without items
+	if result["type"] == "array" {
+		if _, ok := result["items"]; !ok {
+			result["items"] = map[string]interface{}{}
+		}
+	}
+	return result
+}
+
+// alternativeTypes returns type names of oneOf or anyOf schemas
+func alternativeTypes(v interface{}) []string {
+	list, _ := v.([]interface{})
+	types := make([]string, 0, len(list))
+	for _, item := range list {
+		sub, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := sub["$ref"].(string); ok {
+			types = append(types, strings.TrimPrefix(ref, v3RefPrefix))
+		} else if t, ok := sub["type"].(string); ok {
+			types = append(types, t)
+		} else if required, ok := sub["required"].([]interface{}); ok {
+			// k8s uses oneOf to declare mutually exclusive fields
+			fields := make([]string, 0, len(required))
+			for _, f := range required {
+				fields = append(fields, fmt.Sprint(f))
+			}
+			types = append(types, strings.Join(fields, "+"))
+		}
+	}
+	return types
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"encoding/json"
+	"kexplain/pkg/testutil"
+	"reflect"
+	"testing"
+)
+
+func TestConvertV3Schema(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "ref in allOf",
+			in:   `{"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.PodSpec"}], "description": "pod spec", "default": {}}`,
+			want: `{"$ref": "#/definitions/io.k8s.api.core.v1.PodSpec", "description": "pod spec", "default": {}}`,
+		},
+		{
+			name: "nullable ref",
+			in:   `{"$ref": "#/components/schemas/io.k8s.api.core.v1.PodSpec", "nullable": true}`,
+			want: `{"$ref": "#/definitions/io.k8s.api.core.v1.PodSpec", "x-kexplain-nullable": true}`,
+		},
+		{
+			name: "nested properties",
+			in: `{"type": "object", "required": ["name"], "properties": {
+				"name": {"type": "string", "minLength": 1, "x-kexplain-validations": {"minLength": 1}},
+				"ports": {"type": "array", "items": {"$ref": "#/components/schemas/io.k8s.api.core.v1.ContainerPort"}},
+				"labels": {"type": "object", "additionalProperties": {"type": "string", "nullable": true}}
+			}}`,
+			want: `{"type": "object", "required": ["name"], "properties": {
+				"name": {"type": "string", "minLength": 1, "x-kexplain-validations": {"minLength": 1}},
+				"ports": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.api.core.v1.ContainerPort"}},
+				"labels": {"type": "object", "additionalProperties": {"type": "string", "x-kexplain-nullable": true}}
+			}}`,
+		},
+		{
+			name: "int-or-string",
+			in:   `{"anyOf": [{"type": "integer"}, {"type": "string"}], "x-kubernetes-int-or-string": true}`,
+			want: `{"type": "string", "format": "int-or-string", "x-kubernetes-int-or-string": true}`,
+		},
+		{
+			name: "oneOf of required fields",
+			in:   `{"type": "object", "oneOf": [{"required": ["a"]}, {"required": ["b", "c"]}]}`,
+			want: `{"type": "object", "x-kexplain-one-of": ["a", "b+c"]}`,
+		},
+		{
+			name: "v3 only keys are dropped",
+			in:   `{"type": "string", "enum": ["a", "b"], "writeOnly": true, "x-kubernetes-map-type": "atomic"}`,
+			want: `{"type": "string", "enum": ["a", "b"], "x-kexplain-validations": {"enum": ["a", "b"]}, "x-kubernetes-map-type": "atomic"}`,
+		},
+		{
+			name: "array without items",
+			in:   `{"type": "array"}`,
+			want: `{"type": "array", "items": {}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var in map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.in), &in); err != nil {
+				t.Fatalf("fail to parse input: %v", err)
+			}
+			// compare them as JSON, because types of lists differ
+			data, err := json.Marshal(convertV3Schema(in))
+			if err != nil {
+				t.Fatalf("fail to marshal result: %v", err)
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("fail to parse result: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("fail to parse want: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("convertV3Schema() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsV3(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{data: `{"openapi": "3.0.0", "paths": {}}`, want: true},
+		{data: "openapi: 3.0.0\npaths: {}\n", want: true},
+		{data: `{"swagger": "2.0"}`, want: false},
+		{data: "not a document", want: false},
+	}
+	for _, tt := range tests {
+		if got := IsV3([]byte(tt.data)); got != tt.want {
+			t.Errorf("IsV3(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestNewDocumentFromV3(t *testing.T) {
+	doc, err := NewDocumentFromV3(testutil.ReadFile(t, "v3-apps.json"), testutil.ReadFile(t, "v3-core.yaml"))
+	if err != nil {
+		t.Fatalf("NewDocumentFromV3() error = %v", err)
+	}
+	if v := doc.GetInfo().GetVersion(); v != "v1.27.0" {
+		t.Errorf("version = %q, want v1.27.0", v)
+	}
+	if got := len(doc.GetPaths().GetPath()); got != 1 {
+		t.Errorf("paths = %d, want 1", got)
+	}
+	r, err := NewResources(doc)
+	if err != nil {
+		t.Fatalf("NewResources() error = %v", err)
+	}
+	if got, want := len(r.ListResources()), 2; got != want {
+		t.Errorf("ListResources() = %v, want %d kinds", r.ListResources(), want)
+	}
+	replicas := newTestDoc(t, r, testDeploymentGVK, "spec", "replicas")
+	if got := replicas.GetFullPath(); got != "deployment.spec.replicas" {
+		t.Errorf("GetFullPath() = %q, want deployment.spec.replicas", got)
+	}
+}
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"fmt"
+	"kexplain/pkg/suggest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const preserveUnknownFieldsExtKey = "x-kubernetes-preserve-unknown-fields"
+
+// quantityModel is the definition of quantities like `500m`, which has no format in the k8s swagger
+const quantityModel = "io.k8s.apimachinery.pkg.api.resource.Quantity"
+
+// ValidationError is a problem of a field in a manifest
+type ValidationError struct {
+	// Line is the line of the field in the manifest
+	Line int
+	// Path is like `spec.template.spec.containers[0].image`
+	Path    string
+	Message string
+	// Description is the first sentence of the description of the field
+	Description string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("%d: %s: %s", e.Line, e.Path, e.Message)
+}
+
+// Validate checks unknown fields, missing required fields and types of the object in node against the schema s.
+// node is a YAML document or a mapping.
+func Validate(node *yaml.Node, s proto.Schema) []*ValidationError {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	v := &validator{}
+	v.validate(node, nil, s, "")
+	sort.SliceStable(v.errs, func(i, j int) bool { return v.errs[i].Line < v.errs[j].Line })
+	return v.errs
+}
+
+type validator struct {
+	errs []*ValidationError
+}
+
+func (v *validator) addError(node *yaml.Node, s proto.Schema, path, format string, args ...interface{}) {
+	desc := ""
+	if s != nil {
+		desc = firstSentence(s.GetDescription())
+	}
+	v.errs = append(v.errs, &ValidationError{
+		Line:        node.Line,
+		Path:        path,
+		Message:     fmt.Sprintf(format, args...),
+		Description: desc,
+	})
+}
+
+// validate checks node of path, key is the key node of it in the parent mapping, or nil for items and the root
+func (v *validator) validate(node, key *yaml.Node, s proto.Schema, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	// null is the same as the field is absent
+	if node.Kind == yaml.ScalarNode && scalarTag(node) == "!!null" {
+		return
+	}
+	switch t := resolveRef(s).(type) {
+	case *proto.Kind:
+		if node.Kind != yaml.MappingNode {
+			v.addError(node, s, path, "expected object, but got %s", nodeType(node))
+			return
+		}
+		preserveUnknown, _ := t.GetExtensions()[preserveUnknownFieldsExtKey].(bool)
+		present := map[string]bool{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			present[key.Value] = true
+			field, ok := t.Fields[key.Value]
+			if !ok {
+				if !preserveUnknown {
+					v.addUnknownFieldError(key, t, path)
+				}
+				continue
+			}
+			v.validate(value, key, field, joinPath(path, key.Value))
+		}
+		// report missing fields at the key of the object, the mapping starts at its first field
+		at := node
+		if key != nil {
+			at = key
+		}
+		for _, required := range t.RequiredFields {
+			if !present[required] {
+				v.addError(at, t.Fields[required], joinPath(path, required), "missing required field")
+			}
+		}
+	case *proto.Array:
+		if node.Kind != yaml.SequenceNode {
+			v.addError(node, s, path, "expected array, but got %s", nodeType(node))
+			return
+		}
+		for i, item := range node.Content {
+			v.validate(item, nil, t.SubType, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case *proto.Map:
+		if node.Kind != yaml.MappingNode {
+			v.addError(node, s, path, "expected map, but got %s", nodeType(node))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.validate(node.Content[i+1], node.Content[i], t.SubType, joinPath(path, node.Content[i].Value))
+		}
+	case *proto.Primitive:
+		if node.Kind != yaml.ScalarNode {
+			v.addError(node, s, path, "expected %s, but got %s", t.Type, nodeType(node))
+			return
+		}
+		if !scalarMatches(node, t) {
+			v.addError(node, s, path, "expected %s, but got %s %q", t.Type, nodeType(node), node.Value)
+		}
+	}
+}
+
+func (v *validator) addUnknownFieldError(key *yaml.Node, kind *proto.Kind, path string) {
+	msg := "unknown field"
+	if suggestions := suggest.Closest(key.Value, kind.Keys()); len(suggestions) > 0 {
+		msg += ", did you mean " + strings.Join(suggestions, " or ") + "?"
+	}
+	v.errs = append(v.errs, &ValidationError{
+		Line:    key.Line,
+		Path:    joinPath(path, key.Value),
+		Message: msg,
+	})
+}
+
+// scalarMatches returns true if the YAML scalar is valid for the primitive type
+func scalarMatches(node *yaml.Node, p *proto.Primitive) bool {
+	tag := scalarTag(node)
+	switch p.Type {
+	case "integer":
+		return tag == "!!int"
+	case "number":
+		return tag == "!!int" || tag == "!!float"
+	case "boolean":
+		return tag == "!!bool"
+	case "string":
+		switch tag {
+		case "!!str", "!!timestamp":
+			return true
+		case "!!int", "!!float":
+			return acceptsNumber(p)
+		}
+		return false
+	}
+	return true
+}
+
+// acceptsNumber returns true for strings which can be numbers too, like int-or-string ports and quantities like `0.5`
+func acceptsNumber(p *proto.Primitive) bool {
+	intOrString, _ := p.GetExtensions()[intOrStringExtKey].(bool)
+	return intOrString || p.Format == "int-or-string" || p.Format == "quantity" || p.GetPath().String() == quantityModel
+}
+
+var (
+	yaml11Nulls = map[string]bool{"": true, "~": true, "null": true, "Null": true, "NULL": true}
+	yaml11Bools = map[string]bool{
+		"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "n": true, "N": true, "no": true, "No": true, "NO": true,
+		"true": true, "True": true, "TRUE": true, "false": true, "False": true, "FALSE": true,
+		"on": true, "On": true, "ON": true, "off": true, "Off": true, "OFF": true,
+	}
+	yaml11Floats = regexp.MustCompile(`^([-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?|[-+]?\.(inf|Inf|INF)|\.(nan|NaN|NAN))$`)
+)
+
+// scalarTag returns the tag of the scalar resolved by YAML 1.1 like the API server does,
+// in which `yes` and `on` are booleans, and `0x1F` and `1_000` are integers
+func scalarTag(node *yaml.Node) string {
+	if node.Kind != yaml.ScalarNode || node.Style != 0 {
+		// tagged, quoted and block scalars keep their tags
+		return node.Tag
+	}
+	v := node.Value
+	switch {
+	case yaml11Nulls[v]:
+		return "!!null"
+	case yaml11Bools[v]:
+		return "!!bool"
+	}
+	plain := strings.ReplaceAll(v, "_", "")
+	if _, err := strconv.ParseInt(plain, 0, 64); err == nil {
+		return "!!int"
+	}
+	if _, err := strconv.ParseUint(plain, 0, 64); err == nil {
+		return "!!int"
+	}
+	if yaml11Floats.MatchString(plain) {
+		return "!!float"
+	}
+	if node.Tag == "!!timestamp" {
+		return node.Tag
+	}
+	return "!!str"
+}
+
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch scalarTag(node) {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	}
+	return "string"
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate(t *testing.T) {
+	r := newTestResources(t, "deployment.yaml")
+	s := r.LookupResource(testDeploymentGVK)
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+	}{
+		{
+			name: "valid",
+			manifest: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  creationTimestamp: 2021-01-01T00:00:00Z
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: nginx
+  template:
+    spec:
+      containers:
+      - name: nginx
+        livenessProbe:
+          httpGet:
+            port: 80
+        resources:
+          limits:
+            cpu: 0.5
+            memory: 1Gi
+            nvidia.com/gpu: 1
+`,
+			want: []string{},
+		},
+		{
+			name: "int-or-string as a string",
+			manifest: `
+spec:
+  selector: {}
+  template:
+    spec:
+      containers:
+      - name: nginx
+        livenessProbe:
+          httpGet:
+            port: http
+`,
+			want: []string{},
+		},
+		{
+			name: "missing required fields",
+			manifest: `
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx
+`,
+			want: []string{
+				"2: spec.selector: missing required field",
+				"6: spec.template.spec.containers[0].name: missing required field",
+			},
+		},
+		{
+			name: "unknown fields",
+			manifest: `
+spec:
+  replica: 1
+  selector: {}
+  template: {}
+`,
+			want: []string{"3: spec.replica: unknown field, did you mean replicas?"},
+		},
+		{
+			name: "types",
+			manifest: `
+spec:
+  replicas: "2"
+  selector: []
+  template:
+    spec:
+      containers:
+      - name: 1
+        image: true
+        stdin: "yes"
+`,
+			want: []string{
+				`3: spec.replicas: expected integer, but got string "2"`,
+				"4: spec.selector: expected object, but got array",
+				`8: spec.template.spec.containers[0].name: expected string, but got integer "1"`,
+				`9: spec.template.spec.containers[0].image: expected string, but got boolean "true"`,
+				`10: spec.template.spec.containers[0].stdin: expected boolean, but got string "yes"`,
+			},
+		},
+		{
+			name: "null is absent",
+			manifest: `
+metadata:
+spec:
+  replicas: ~
+  selector: {}
+  template: {}
+`,
+			want: []string{},
+		},
+		{
+			name: "YAML 1.1 scalars",
+			manifest: `
+spec:
+  replicas: 0x_1F
+  selector: {}
+  template:
+    spec:
+      containers:
+      - name: nginx
+        stdin: yes
+      - name: on
+        stdin: off
+      - name: 1_000
+        image: .inf
+        stdin: "on"
+      - name: .NaN
+        image: !!str yes
+        stdin: N
+`,
+			want: []string{
+				`10: spec.template.spec.containers[1].name: expected string, but got boolean "on"`,
+				`12: spec.template.spec.containers[2].name: expected string, but got integer "1_000"`,
+				`13: spec.template.spec.containers[2].image: expected string, but got number ".inf"`,
+				`14: spec.template.spec.containers[2].stdin: expected boolean, but got string "on"`,
+				`15: spec.template.spec.containers[3].name: expected string, but got number ".NaN"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.manifest), &node); err != nil {
+				t.Fatalf("fail to parse manifest: %v", err)
+			}
+			got := []string{}
+			for _, err := range Validate(&node, s) {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	"gopkg.in/yaml.v3"
+)
+
+// liftValidations keeps validations of schemas in the v2 document like enum and pattern in extensions,
+// which are dropped by proto models. Schemas converted from v3 have the extension already, see convertV3Schema.
+func liftValidations(doc *openapi_v2.Document) {
+	for _, named := range doc.GetDefinitions().GetAdditionalProperties() {
+		liftSchemaValidations(named.GetValue())
+	}
+}
+
+func liftSchemaValidations(s *openapi_v2.Schema) {
+	if s == nil {
+		return
+	}
+	for _, named := range s.GetProperties().GetAdditionalProperties() {
+		liftSchemaValidations(named.GetValue())
+	}
+	for _, item := range s.GetItems().GetSchema() {
+		liftSchemaValidations(item)
+	}
+	liftSchemaValidations(s.GetAdditionalProperties().GetSchema())
+	for _, sub := range s.GetAllOf() {
+		liftSchemaValidations(sub)
+	}
+
+	for _, ext := range s.GetVendorExtension() {
+		if ext.GetName() == validationsExtKey {
+			return
+		}
+	}
+	// v2 schemas can't tell zero from absence, so zero values are skipped
+	validations := map[string]interface{}{}
+	if enum := s.GetEnum(); len(enum) > 0 {
+		values := make([]interface{}, 0, len(enum))
+		for _, e := range enum {
+			var v interface{}
+			if err := yaml.Unmarshal([]byte(e.GetYaml()), &v); err == nil {
+				values = append(values, v)
+			}
+		}
+		validations["enum"] = values
+	}
+	if s.GetPattern() != "" {
+		validations["pattern"] = s.GetPattern()
+	}
+	for key, v := range map[string]float64{"minimum": s.GetMinimum(), "maximum": s.GetMaximum(), "multipleOf": s.GetMultipleOf()} {
+		if v != 0 {
+			validations[key] = v
+		}
+	}
+	for key, v := range map[string]int64{
+		"minLength": s.GetMinLength(), "maxLength": s.GetMaxLength(),
+		"minItems": s.GetMinItems(), "maxItems": s.GetMaxItems(),
+		"minProperties": s.GetMinProperties(), "maxProperties": s.GetMaxProperties(),
+	} {
+		if v != 0 {
+			validations[key] = v
+		}
+	}
+	for key, v := range map[string]bool{
+		"exclusiveMinimum": s.GetExclusiveMinimum(), "exclusiveMaximum": s.GetExclusiveMaximum(), "uniqueItems": s.GetUniqueItems(),
+	} {
+		if v {
+			validations[key] = v
+		}
+	}
+	if len(validations) == 0 {
+		return
+	}
+	data, err := yaml.Marshal(validations)
+	if err != nil {
+		return
+	}
+	s.VendorExtension = append(s.VendorExtension, &openapi_v2.NamedAny{
+		Name:  validationsExtKey,
+		Value: &openapi_v2.Any{Yaml: string(data)},
+	})
+}
+
+// v3ValidationKeys are validations kept in the extension when converting v3 schemas
+var v3ValidationKeys = []string{
+	"enum", "pattern", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+	"minLength", "maxLength", "minItems", "maxItems", "uniqueItems", "minProperties", "maxProperties",
+}
+
+// v3Validations returns validations of a v3 schema for the extension, nil if there are none
+func v3Validations(s map[string]interface{}) map[string]interface{} {
+	validations := map[string]interface{}{}
+	for _, key := range v3ValidationKeys {
+		if v, ok := s[key]; ok {
+			validations[key] = v
+		}
+	}
+	if len(validations) == 0 {
+		return nil
+	}
+	return validations
+}
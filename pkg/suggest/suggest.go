@@ -0,0 +1,81 @@
+// Package suggest finds words close to mistyped ones for "did you mean" messages
+package suggest
+
+import (
+	"sort"
+	"strings"
+)
+
+const maxSuggestions = 3
+
+// Closest returns candidates close to the word by edit distance or prefix, the closest first
+func Closest(word string, candidates []string) []string {
+	word = strings.ToLower(word)
+	type scored struct {
+		candidate string
+		distance  int
+	}
+	maxDistance := len(word)/3 + 1
+	seen := map[string]bool{}
+	matches := []scored{}
+	for _, c := range candidates {
+		if seen[c] || c == "" {
+			continue
+		}
+		seen[c] = true
+		lower := strings.ToLower(c)
+		d := editDistance(word, lower)
+		if strings.HasPrefix(lower, word) {
+			// a prefix is as good as a typo
+			d = min(d, 1)
+		}
+		if d <= maxDistance {
+			matches = append(matches, scored{candidate: c, distance: d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].candidate < matches[j].candidate
+	})
+	result := []string{}
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		result = append(result, matches[i].candidate)
+	}
+	return result
+}
+
+// editDistance is the Damerau-Levenshtein distance, a swap of adjacent letters costs 1
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// d[i][j] is the distance between ra[:i] and rb[:j]
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(min(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
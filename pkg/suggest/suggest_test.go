@@ -0,0 +1,45 @@
+package suggest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"deployments", "deployment", "deploy", "daemonsets", "pods", "pod", "po", "services"}
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{word: "deploymnet", want: []string{"deployment", "deployments", "deploy"}},
+		{word: "dpeloy", want: []string{"deploy"}},
+		{word: "pdo", want: []string{"po", "pod", "pods"}},
+		{word: "Servics", want: []string{"services"}},
+		{word: "configmap", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := Closest(tt.word, candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Closest(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "pod", b: "pod", want: 0},
+		{a: "pod", b: "pdo", want: 1},
+		{a: "pod", b: "pods", want: 1},
+		{a: "", b: "abc", want: 3},
+		{a: "kitten", b: "sitting", want: 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package testutil loads fixtures of tests, which are files in testdata of the repository
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+)
+
+// Path returns the path of a file in testdata
+func Path(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "testdata", name)
+}
+
+// ReadFile returns the content of a file in testdata
+func ReadFile(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(Path(name))
+	if err != nil {
+		t.Fatalf("fail to read fixture: %v", err)
+	}
+	return data
+}
+
+// Document returns the swagger document of a file in testdata
+func Document(t testing.TB, name string) *openapi_v2.Document {
+	t.Helper()
+	doc, err := openapi_v2.ParseDocument(ReadFile(t, name))
+	if err != nil {
+		t.Fatalf("fail to parse fixture %s: %v", name, err)
+	}
+	return doc
+}
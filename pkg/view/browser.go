@@ -0,0 +1,139 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// BrowserItem is a resource in the Browser
+type BrowserItem struct {
+	GVK        schema.GroupVersionKind
+	Plural     string
+	ShortNames []string
+	// nil when it's unknown
+	Namespaced *bool
+}
+
+func (i *BrowserItem) columns() []string {
+	namespaced := ""
+	if i.Namespaced != nil {
+		namespaced = "false"
+		if *i.Namespaced {
+			namespaced = "true"
+		}
+	}
+	return []string{i.Plural, strings.Join(i.ShortNames, ","), i.GVK.GroupVersion().String(), namespaced, i.GVK.Kind}
+}
+
+var browserHeaders = []string{"NAME", "SHORTNAMES", "APIVERSION", "NAMESPACED", "KIND"}
+
+// Browser is a filterable list of resources like `kubectl api-resources`,
+// which is shown when no resource is given.
+type Browser struct {
+	*tview.Flex
+	filter *tview.InputField
+	table  *tview.Table
+
+	items []BrowserItem
+	// items matching the filter
+	shown []BrowserItem
+
+	selectFn func(item BrowserItem)
+	stopFn   func()
+}
+
+// NewBrowser returns a Browser of items
+func NewBrowser(items []BrowserItem) *Browser {
+	b := &Browser{
+		Flex:  tview.NewFlex().SetDirection(tview.FlexRow),
+		items: items,
+	}
+	b.filter = tview.NewInputField().
+		SetLabel("/").
+		SetPlaceholder("type to filter, Enter to explain, Esc to quit").
+		SetFieldWidth(0).
+		SetFieldBackgroundColor(plainColor).
+		SetChangedFunc(func(text string) { b.update(text) })
+	b.filter.SetInputCapture(b.captureFilterInput)
+	b.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+	b.table.SetSelectedStyle(highlightStyle)
+	b.table.SetBackgroundColor(plainColor)
+
+	b.AddItem(b.table, 0, 1, false).
+		AddItem(b.filter, 1, 0, true)
+	b.update("")
+	return b
+}
+
+// SetSelectFn sets the callback, which is called when pressing Enter on a resource.
+func (b *Browser) SetSelectFn(fn func(item BrowserItem)) {
+	b.selectFn = fn
+}
+
+// SetStopFn sets the stop callback, which is called when pressing Esc with an empty filter.
+func (b *Browser) SetStopFn(fn func()) {
+	b.stopFn = fn
+}
+
+// update shows items containing the filter text in any column
+func (b *Browser) update(filter string) {
+	filter = strings.ToLower(filter)
+	b.shown = b.shown[:0]
+	for _, item := range b.items {
+		if filter == "" || strings.Contains(strings.ToLower(strings.Join(item.columns(), " ")), filter) {
+			b.shown = append(b.shown, item)
+		}
+	}
+
+	b.table.Clear()
+	for col, header := range browserHeaders {
+		b.table.SetCell(0, col, tview.NewTableCell(header).SetSelectable(false))
+	}
+	for row, item := range b.shown {
+		for col, text := range item.columns() {
+			cell := tview.NewTableCell(tview.Escape(text))
+			if col == 0 {
+				cell.SetTextColor(tcell.ColorGreen)
+			}
+			b.table.SetCell(row+1, col, cell)
+		}
+	}
+	b.table.Select(1, 0)
+	b.table.ScrollToBeginning()
+}
+
+// captureFilterInput passes moving keys to the table, others are typed into the filter
+func (b *Browser) captureFilterInput(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyCtrlP, tcell.KeyCtrlN:
+		key := event.Key()
+		switch key {
+		case tcell.KeyCtrlP:
+			key = tcell.KeyUp
+		case tcell.KeyCtrlN:
+			key = tcell.KeyDown
+		}
+		b.table.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), func(p tview.Primitive) {})
+		return nil
+	case tcell.KeyEnter:
+		row, _ := b.table.GetSelection()
+		if row >= 1 && row <= len(b.shown) && b.selectFn != nil {
+			b.selectFn(b.shown[row-1])
+		}
+		return nil
+	case tcell.KeyEscape:
+		if b.filter.GetText() != "" {
+			b.filter.SetText("")
+		} else if b.stopFn != nil {
+			b.stopFn()
+		}
+		return nil
+	}
+	return event
+}
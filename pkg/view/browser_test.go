@@ -0,0 +1,28 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBrowserSelect(t *testing.T) {
+	b := NewBrowser([]BrowserItem{
+		{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, Plural: "pods", ShortNames: []string{"po"}},
+		{GVK: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}, Plural: "daemonsets", ShortNames: []string{"ds"}},
+		{GVK: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, Plural: "deployments", ShortNames: []string{"deploy"}},
+	})
+	var selected *BrowserItem
+	b.SetSelectFn(func(item BrowserItem) { selected = &item })
+
+	b.filter.SetText("APPS")
+	if len(b.shown) != 2 {
+		t.Fatalf("shown = %v, want 2 items of apps", b.shown)
+	}
+	b.captureFilterInput(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	b.captureFilterInput(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if selected == nil || selected.Plural != "deployments" {
+		t.Errorf("selected = %v, want deployments", selected)
+	}
+}
@@ -10,7 +10,11 @@ type linesCalculator struct {
 	y      int
 	indent int
 	wrap   int
-	lines  []string
+	// escape lines for tview
+	escape bool
+	// hide fields marked deprecated or alpha
+	hideUnstable bool
+	lines        []string
 }
 
 const defaultWrap = 80
@@ -20,12 +24,13 @@ func newLinesCalculator() *linesCalculator {
 		y:      0,
 		indent: 0,
 		wrap:   defaultWrap,
+		escape: true,
 		lines:  []string{},
 	}
 }
 
 func (c *linesCalculator) appendLine(line string) {
-	c.appendLineWithEscape(line, true)
+	c.appendLineWithEscape(line, c.escape)
 }
 
 func (c *linesCalculator) appendLineWithEscape(line string, escape bool) {
@@ -0,0 +1,202 @@
+package view
+
+import (
+	"fmt"
+	"kexplain/pkg/model"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// commands are commands which can be typed after `:`, with a space if it has args
+var commands = []string{"goto ", "history", "search "}
+
+// DocFinder finds docs of paths like `deploy.spec` for `:goto`, and fields of all resources for `:search`
+// and for fields using the type of a doc
+type DocFinder interface {
+	FindDoc(path string) (*model.Doc, error)
+	// ResourceNames returns names of resources like `deploy`, which are completed in paths
+	ResourceNames() []string
+	// Search returns fields whose names or descriptions contain the query
+	Search(query string) []*model.SearchResult
+	// UsedBy returns fields of all resources whose type is the one of the doc, and the number of them before truncated
+	UsedBy(doc *model.Doc) ([]*model.UsedByResult, int)
+}
+
+// SetDocFinder sets the finder of `:goto` and `:search`, which find fields in the resource of the page only without it.
+func (p *Page) SetDocFinder(finder DocFinder) {
+	p.finder = finder
+}
+
+// runCommand runs the command typed after `:`
+func (p *Page) runCommand(command string) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return
+	}
+	switch fields[0] {
+	case "goto":
+		if len(fields) != 2 {
+			p.message = "usage: goto <path>"
+			return
+		}
+		doc, err := p.findDoc(fields[1])
+		if err != nil {
+			p.message = err.Error()
+			return
+		}
+		p.visit(doc)
+	case "history":
+		p.showHistory()
+	case "search":
+		query := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(command), "search"))
+		if query == "" {
+			p.message = "usage: search <text>"
+			return
+		}
+		p.showSearchResults(query)
+	default:
+		p.message = fmt.Sprintf("unknown command %q", fields[0])
+	}
+}
+
+// showSearchResults shows fields of all resources whose names or descriptions contain the query
+func (p *Page) showSearchResults(query string) {
+	var results []*model.SearchResult
+	if p.finder != nil {
+		results = p.finder.Search(query)
+	} else {
+		results = model.NewSearchIndex([]*model.Doc{p.doc.FindRootDoc()}).Search(query)
+	}
+	if len(results) == 0 {
+		p.message = fmt.Sprintf("%q is not found", query)
+		return
+	}
+
+	list := newPageList().ShowSecondaryText(true)
+	for _, r := range results {
+		result := r
+		list.AddItem(
+			fmt.Sprintf("[green]%s[-] (%s)", tview.Escape(r.Path), r.GroupVersion()),
+			"  "+tview.Escape(r.Snippet),
+			0,
+			func() {
+				p.list = nil
+				doc, err := result.Doc()
+				if err != nil {
+					p.message = err.Error()
+					return
+				}
+				p.visit(doc)
+			})
+	}
+	p.showList(fmt.Sprintf("%d results of %q", len(results), query), list)
+}
+
+// showUsedBy shows fields of all resources whose type is the one of the doc, like ones of PodSpec
+func (p *Page) showUsedBy() {
+	name := p.doc.GetModelName()
+	if name == "" {
+		p.message = "the type of " + p.doc.GetFullPath() + " is not a definition"
+		return
+	}
+	var results []*model.UsedByResult
+	var total int
+	if p.finder != nil {
+		results, total = p.finder.UsedBy(p.doc)
+	} else {
+		results, total = model.NewUsedByIndex([]*model.Doc{p.doc.FindRootDoc()}).UsedBy(p.doc)
+	}
+	if len(results) == 0 {
+		p.message = name + " is not used by other fields"
+		return
+	}
+
+	list := newPageList()
+	for _, r := range results {
+		result := r
+		list.AddItem(fmt.Sprintf("[green]%s[-] (%s)", tview.Escape(r.Path), r.GroupVersion()), "", 0, func() {
+			p.list = nil
+			doc, err := result.Doc()
+			if err != nil {
+				p.message = err.Error()
+				return
+			}
+			p.visit(doc)
+		})
+	}
+	title := fmt.Sprintf("%d fields using %s", total, name)
+	if total > len(results) {
+		title = fmt.Sprintf("first %d of %d fields using %s", len(results), total, name)
+	}
+	p.showList(title, list)
+}
+
+// findDoc returns the doc of a path like `deploy.spec`, or a path in the resource of the page
+// like `pod.spec` without the finder
+func (p *Page) findDoc(path string) (*model.Doc, error) {
+	if p.finder != nil {
+		return p.finder.FindDoc(path)
+	}
+	segments := strings.Split(strings.TrimSuffix(path, "."), ".")
+	if segments[0] != strings.ToLower(p.doc.GetKind()) {
+		return nil, fmt.Errorf("%q is not in %s", path, strings.ToLower(p.doc.GetKind()))
+	}
+	return p.doc.FindPathDoc(segments[1:])
+}
+
+// completeCommand returns commands, resources or fields starting with the last word of the text
+func (p *Page) completeCommand(text string) []string {
+	if p.command != ":" || text == "" {
+		return nil
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 1 && !strings.HasSuffix(text, " ") {
+		return completeWords("", fields[0], commands, text)
+	}
+	if fields[0] != "goto" || len(fields) > 2 {
+		return nil
+	}
+	if len(fields) == 1 {
+		// too many resources to complete
+		return nil
+	}
+	path := fields[1]
+	prefix := "goto "
+	candidates := []string{}
+	if i := strings.LastIndex(path, "."); i < 0 {
+		if p.finder != nil {
+			candidates = p.finder.ResourceNames()
+		} else {
+			candidates = []string{strings.ToLower(p.doc.GetKind())}
+		}
+	} else {
+		prefix += path[:i+1]
+		path = path[i+1:]
+		doc, err := p.findDoc(strings.TrimSuffix(prefix[len("goto "):], "."))
+		if err != nil {
+			return nil
+		}
+		if kind := doc.GetDocKind(); kind != nil {
+			candidates = kind.Keys()
+		}
+	}
+	return completeWords(prefix, path, candidates, text)
+}
+
+// completeWords returns prefix + candidates starting with word,
+// nothing when the only one is the text which doesn't need completing
+func completeWords(prefix, word string, candidates []string, text string) []string {
+	entries := []string{}
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			entries = append(entries, prefix+c)
+		}
+	}
+	sort.Strings(entries)
+	if len(entries) == 1 && entries[0] == text {
+		return nil
+	}
+	return entries
+}
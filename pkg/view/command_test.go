@@ -0,0 +1,44 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleteCommand(t *testing.T) {
+	p := NewPage(newTestDoc(t, "deployment.yaml", testDeploymentGVK))
+	p.command = ":"
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "", want: nil},
+		{text: "g", want: []string{"goto "}},
+		{text: "history", want: nil},
+		{text: "goto ", want: nil},
+		{text: "goto dep", want: []string{"goto deployment"}},
+		{text: "goto deployment.", want: []string{"goto deployment.apiVersion", "goto deployment.kind", "goto deployment.metadata", "goto deployment.spec"}},
+		{text: "goto deployment.spec.te", want: []string{"goto deployment.spec.template"}},
+		{text: "goto deployment.sepc.", want: nil},
+		{text: "history deployment", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := p.completeCommand(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completeCommand(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunGotoCommand(t *testing.T) {
+	p := NewPage(newTestDoc(t, "deployment.yaml", testDeploymentGVK))
+	p.runCommand("goto deployment.spec.template")
+	if got := p.doc.GetFullPath(); got != "deployment.spec.template" {
+		t.Errorf("doc = %q, want deployment.spec.template", got)
+	}
+	p.runCommand("goto pod.spec")
+	if p.message == "" || p.doc.GetFullPath() != "deployment.spec.template" {
+		t.Errorf("message = %q, doc = %q, want an error and the same doc", p.message, p.doc.GetFullPath())
+	}
+}
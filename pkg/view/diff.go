@@ -0,0 +1,161 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"kexplain/pkg/model"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// maxDiffSummaryHeight is the max height of changes above pages in DiffView, which scroll in it
+const maxDiffSummaryHeight = 10
+
+// DiffView shows changes of fields, and pages of the old and new docs side by side
+type DiffView struct {
+	*tview.Flex
+	summary *tview.List
+	pages   [2]*Page
+	// old page, new page and the summary, which are focused in turn by Ctrl-W
+	panes []tview.Primitive
+	// index of the focused pane
+	focused int
+	stopFn  func()
+}
+
+// NewDiffView returns a DiffView of changes from oldDoc to newDoc.
+// Selecting a change in the summary shows the field in both pages.
+func NewDiffView(changes []model.FieldChange, oldDoc, newDoc *model.Doc) *DiffView {
+	v := &DiffView{
+		Flex:    tview.NewFlex().SetDirection(tview.FlexRow),
+		summary: newPageList(),
+		pages:   [2]*Page{NewPage(oldDoc), NewPage(newDoc)},
+	}
+	v.panes = []tview.Primitive{v.pages[0], v.pages[1], v.summary}
+	lines := diffLines(changes)
+	for i, line := range lines {
+		change := changes[i]
+		v.summary.AddItem(tview.Escape(line), "", 0, func() { v.showChange(change) })
+	}
+	if len(lines) == 0 {
+		v.summary.AddItem("No changes", "", 0, nil)
+	}
+	v.summary.SetBorder(true).
+		SetTitle(" Changes, Enter to show the field, Ctrl-W to switch panes ").
+		SetBackgroundColor(plainColor)
+	height := v.summary.GetItemCount() + 2
+	if height > maxDiffSummaryHeight {
+		height = maxDiffSummaryHeight
+	}
+	pages := tview.NewFlex().
+		AddItem(v.pages[0], 0, 1, true).
+		AddItem(nil, 1, 0, false).
+		AddItem(v.pages[1], 0, 1, false)
+	v.AddItem(v.summary, height, 0, false).
+		AddItem(pages, 0, 1, true)
+	return v
+}
+
+// showChange opens the changed field in both pages, or its parent in the page without it like added fields
+func (v *DiffView) showChange(change model.FieldChange) {
+	path := strings.Split(change.Path, ".")
+	for _, p := range v.pages {
+		root := p.GetDoc().FindRootDoc()
+		doc, err := root.FindPathDoc(path)
+		if err != nil {
+			if doc, err = root.FindPathDoc(path[:len(path)-1]); err != nil {
+				continue
+			}
+		}
+		p.Open(doc)
+	}
+}
+
+// SetStopFn sets the stop callback, which is called when pressing q/Q.
+func (v *DiffView) SetStopFn(fn func()) {
+	v.stopFn = fn
+	for _, p := range v.pages {
+		p.SetStopFn(fn)
+	}
+}
+
+// SetVersions sets versions shown at the bottom of the old and new pages.
+func (v *DiffView) SetVersions(oldVersion, newVersion string) {
+	v.pages[0].SetVersion(oldVersion)
+	v.pages[1].SetVersion(newVersion)
+}
+
+// InputHandler is override of Flex, which handles keyboard inputs.
+func (v *DiffView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if event.Key() == tcell.KeyCtrlW {
+			v.focused = (v.focused + 1) % len(v.panes)
+			setFocus(v.panes[v.focused])
+			return
+		}
+		if v.panes[v.focused] == v.summary && event.Key() == tcell.KeyRune {
+			// like lists in pages
+			switch event.Rune() {
+			case 'q', 'Q':
+				if v.stopFn != nil {
+					v.stopFn()
+				}
+				return
+			case 'j':
+				event = tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+			case 'k':
+				event = tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+			}
+		}
+		v.Flex.InputHandler()(event, setFocus)
+	})
+}
+
+// PrintKindDiffs writes changed kinds and their changes of fields
+func PrintKindDiffs(w io.Writer, diffs []model.KindDiff) error {
+	for _, d := range diffs {
+		name := fmt.Sprintf("%s (%s)", d.Kind, d.GroupVersion())
+		if !d.OldGroupVersion.Empty() {
+			name = fmt.Sprintf("%s (%s -> %s)", d.Kind, d.OldGroupVersion, d.GroupVersion())
+		}
+		var err error
+		switch {
+		case d.Added:
+			_, err = fmt.Fprintf(w, "+ %s\n", name)
+		case d.Removed:
+			_, err = fmt.Fprintf(w, "- %s\n", name)
+		default:
+			_, err = fmt.Fprintf(w, "~ %s\n", name)
+			for _, l := range diffLines(d.Changes) {
+				if err != nil {
+					break
+				}
+				_, err = fmt.Fprintf(w, "    %s\n", l)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffLines returns lines like `+ spec.foo <string>` for changes
+func diffLines(changes []model.FieldChange) []string {
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		switch c.Change {
+		case model.FieldAdded:
+			lines = append(lines, fmt.Sprintf("+ %s <%s>", c.Path, c.NewType))
+		case model.FieldRemoved:
+			lines = append(lines, fmt.Sprintf("- %s <%s>", c.Path, c.OldType))
+		case model.FieldRetyped:
+			lines = append(lines, fmt.Sprintf("~ %s <%s> -> <%s>", c.Path, c.OldType, c.NewType))
+		case model.FieldDescriptionChanged:
+			lines = append(lines, fmt.Sprintf("* %s description changed", c.Path))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestDiffView(t *testing.T) {
+	oldDoc := newTestDoc(t, "deployment.yaml", testDeploymentGVK)
+	newDoc := newTestDoc(t, "deployment-changed.yaml", testDeploymentGVK)
+	changes := model.DiffDocs(oldDoc, newDoc)
+	v := NewDiffView(changes, oldDoc, newDoc)
+	if got := v.summary.GetItemCount(); got != len(changes) {
+		t.Fatalf("items of the summary = %d, want %d", got, len(changes))
+	}
+
+	var focused tview.Primitive
+	setFocus := func(p tview.Primitive) { focused = p }
+	for _, want := range []tview.Primitive{v.pages[1], v.summary, v.pages[0]} {
+		v.InputHandler()(tcell.NewEventKey(tcell.KeyCtrlW, 0, tcell.ModNone), setFocus)
+		if focused != want {
+			t.Fatalf("focused = %d, want %v after Ctrl-W", v.focused, want)
+		}
+	}
+
+	tests := []struct {
+		path    string
+		wantOld string
+		wantNew string
+	}{
+		{path: "spec.replicas", wantOld: "deployment.spec.replicas", wantNew: "deployment.spec.replicas"},
+		// added and removed fields show their parents in the other page
+		{path: "spec.template.spec.containers.tty", wantOld: "deployment.spec.template.spec.containers", wantNew: "deployment.spec.template.spec.containers.tty"},
+		{path: "spec.template.spec.nodeName", wantOld: "deployment.spec.template.spec.nodeName", wantNew: "deployment.spec.template.spec"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			for i, c := range changes {
+				if c.Path == tt.path {
+					v.summary.SetCurrentItem(i)
+				}
+			}
+			v.summary.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), setFocus)
+			if got := v.pages[0].GetDoc().GetFullPath(); got != tt.wantOld {
+				t.Errorf("old page = %s, want %s", got, tt.wantOld)
+			}
+			if got := v.pages[1].GetDoc().GetFullPath(); got != tt.wantNew {
+				t.Errorf("new page = %s, want %s", got, tt.wantNew)
+			}
+		})
+	}
+}
@@ -11,11 +11,19 @@ type drawCtx struct {
 	y      int
 	baseY  int
 	width  int
+	// screen Y of the top of the page and below the last line, lines out of them are not drawn
+	top    int
+	bottom int
 }
 
 func (d *drawCtx) drawY() int {
 	// skip header
-	return d.y - d.baseY + headerHeight
+	return d.y - d.baseY + d.top + headerHeight
+}
+
+// visible returns false for the header and lines out of the page
+func (d *drawCtx) visible(y int) bool {
+	return y >= d.top+headerHeight && y < d.bottom
 }
 
 func (d *drawCtx) drawLineWithEscape(text string, color tcell.Color, escape bool) int {
@@ -32,8 +40,7 @@ func (d *drawCtx) print(text string, x int, color tcell.Color) (int, int) {
 		return 0, 0
 	}
 	y := d.drawY()
-	// skip header. tview.Print will check others
-	if y < headerHeight {
+	if !d.visible(y) {
 		return 0, 0
 	}
 	return tview.Print(d.screen, text, x, y, d.width, tview.AlignLeft, color)
@@ -46,8 +53,7 @@ func (d *drawCtx) drawHorizontalLine(y int, color tcell.Color) {
 }
 
 func (d *drawCtx) overrideContent(s string, begin int, y int, style tcell.Style) {
-	// skip header. tview.Print will check others
-	if y < headerHeight {
+	if !d.visible(y) {
 		return
 	}
 	for i, r := range s {
@@ -0,0 +1,80 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+
+	"github.com/rivo/tview"
+)
+
+// historyEntry is a visited doc with the position in it
+type historyEntry struct {
+	doc *model.Doc
+	// nil until leaving the entry
+	data *pageData
+}
+
+// visit shows the doc as a new history entry, entries after the current one are dropped like browsers
+func (p *Page) visit(doc *model.Doc) {
+	p.history[p.historyIdx].data = p.pageData
+	p.history = append(p.history[:p.historyIdx+1], &historyEntry{doc: doc})
+	p.historyIdx++
+	p.setDoc(doc)
+	p.resetData()
+}
+
+// goHistory shows the history entry of idx with the position when leaving it
+func (p *Page) goHistory(idx int) {
+	if idx < 0 || idx >= len(p.history) || idx == p.historyIdx {
+		return
+	}
+	p.history[p.historyIdx].data = p.pageData
+	p.historyIdx = idx
+	entry := p.history[idx]
+	p.setDoc(entry.doc)
+	if entry.data == nil {
+		p.resetData()
+		return
+	}
+	p.pageData = entry.data
+	p.calLines()
+}
+
+// goBack goes back in the history, or to the parent doc at the first entry like opening `deploy.spec`
+func (p *Page) goBack() {
+	if p.historyIdx == 0 {
+		parent := p.doc.FindParentDoc()
+		if parent == nil {
+			return
+		}
+		p.history = append([]*historyEntry{{doc: parent}}, p.history...)
+		p.historyIdx++
+	}
+	p.goHistory(p.historyIdx - 1)
+}
+
+// goForward goes forward in the history, returns false if it's the latest entry
+func (p *Page) goForward() bool {
+	if p.historyIdx+1 >= len(p.history) {
+		return false
+	}
+	p.goHistory(p.historyIdx + 1)
+	return true
+}
+
+// showHistory shows the list of the history, the latest one at the top
+func (p *Page) showHistory() {
+	list := newPageList()
+	for i := len(p.history) - 1; i >= 0; i-- {
+		idx := i
+		text := "  " + p.history[i].doc.GetFullPath()
+		if i == p.historyIdx {
+			text = "* " + p.history[i].doc.GetFullPath()
+		}
+		list.AddItem(tview.Escape(text), "", 0, func() {
+			p.list = nil
+			p.goHistory(idx)
+		})
+	}
+	list.SetCurrentItem(len(p.history) - 1 - p.historyIdx)
+	p.showList("history", list)
+}
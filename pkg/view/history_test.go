@@ -0,0 +1,43 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPageHistory(t *testing.T) {
+	p := NewPage(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec"))
+	p.visit(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec", "template"))
+	p.visit(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec", "template", "spec"))
+
+	p.goBack()
+	p.goBack()
+	if got := p.doc.GetFullPath(); got != "deployment.spec" {
+		t.Errorf("doc after going back = %q, want deployment.spec", got)
+	}
+	if !p.goForward() || p.doc.GetFullPath() != "deployment.spec.template" {
+		t.Errorf("doc after going forward = %q, want deployment.spec.template", p.doc.GetFullPath())
+	}
+
+	// entries after the current one are dropped
+	p.visit(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec", "selector"))
+	if p.goForward() {
+		t.Errorf("goForward() = true at the latest entry")
+	}
+
+	// going back at the first entry opens the parent
+	p.goHistory(0)
+	p.goBack()
+	want := []string{"deployment", "deployment.spec", "deployment.spec.template", "deployment.spec.selector"}
+	if got := historyPaths(p); !reflect.DeepEqual(got, want) || p.historyIdx != 0 {
+		t.Errorf("history = %v at %d, want %v at 0", got, p.historyIdx, want)
+	}
+}
+
+func historyPaths(p *Page) []string {
+	paths := []string{}
+	for _, entry := range p.history {
+		paths = append(paths, entry.doc.GetFullPath())
+	}
+	return paths
+}
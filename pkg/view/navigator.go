@@ -0,0 +1,193 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const navigatorWidth = 32
+
+// Navigator is a Page with a collapsible tree of object fields of the resource on the left.
+// Moving in the tree shows the doc of the field in the page, and the tree follows the doc of the page.
+type Navigator struct {
+	*tview.Flex
+	page *Page
+	tree *tview.TreeView
+	// the tree is shown
+	showTree bool
+	// a doc has been opened since focusing the tree,
+	// docs opened later replace it, so that moving in the tree adds only one history entry
+	opened bool
+}
+
+// navigatorNode is the reference of a node in the tree
+type navigatorNode struct {
+	name   string
+	doc    *model.Doc
+	parent *tview.TreeNode
+	// children are added when the node is expanded for the first time,
+	// so that recursive types like JSONSchemaProps can be shown
+	loaded bool
+}
+
+// NewNavigator returns a Navigator of the page, whose tree is hidden until pressing t
+func NewNavigator(page *Page) *Navigator {
+	n := &Navigator{
+		Flex: tview.NewFlex(),
+		page: page,
+	}
+	n.tree = tview.NewTreeView().
+		SetGraphicsColor(tcell.ColorGreen).
+		SetChangedFunc(n.openNode).
+		SetSelectedFunc(n.toggleNode)
+	n.tree.SetBackgroundColor(plainColor)
+	n.tree.SetBorder(true)
+	page.SetChangedFn(n.follow)
+	n.follow(page.GetDoc())
+
+	n.AddItem(page, 0, 1, true)
+	return n
+}
+
+// GetPage returns the page of the navigator
+func (n *Navigator) GetPage() *Page {
+	return n.page
+}
+
+// SetShowTree sets whether the tree is shown.
+func (n *Navigator) SetShowTree(show bool) {
+	if n.showTree == show {
+		return
+	}
+	n.showTree = show
+	n.Clear()
+	if show {
+		n.AddItem(n.tree, navigatorWidth, 0, false)
+	}
+	n.AddItem(n.page, 0, 1, true)
+}
+
+// follow selects the node of the doc, the tree is rebuilt if the doc is of another resource
+func (n *Navigator) follow(doc *model.Doc) {
+	root := n.tree.GetRoot()
+	if root == nil || root.GetReference().(*navigatorNode).doc.GetGroupVersionKind() != doc.GetGroupVersionKind() {
+		root = newNavigatorTreeNode(doc.FindRootDoc(), strings.ToLower(doc.GetKind()), nil)
+		n.tree.SetRoot(root)
+	}
+
+	node := root
+	for _, name := range doc.GetFieldsPath() {
+		expandNavigatorNode(node)
+		var found *tview.TreeNode
+		for _, child := range node.GetChildren() {
+			if child.GetReference().(*navigatorNode).name == name {
+				found = child
+				break
+			}
+		}
+		if found == nil {
+			break
+		}
+		node = found
+	}
+	n.tree.SetCurrentNode(node)
+}
+
+// openNode shows the doc of the node in the page
+func (n *Navigator) openNode(node *tview.TreeNode) {
+	doc := node.GetReference().(*navigatorNode).doc
+	if doc.GetFullPath() == n.page.GetDoc().GetFullPath() {
+		return
+	}
+	if n.opened {
+		n.page.Replace(doc)
+	} else {
+		n.page.Open(doc)
+		n.opened = true
+	}
+}
+
+// toggleNode expands or collapses the node
+func (n *Navigator) toggleNode(node *tview.TreeNode) {
+	if node.IsExpanded() && len(node.GetChildren()) > 0 {
+		node.Collapse()
+		return
+	}
+	expandNavigatorNode(node)
+}
+
+func newNavigatorTreeNode(doc *model.Doc, name string, parent *tview.TreeNode) *tview.TreeNode {
+	return tview.NewTreeNode("+ " + name).
+		SetReference(&navigatorNode{name: name, doc: doc, parent: parent}).
+		SetColor(tcell.ColorGreen).
+		SetExpanded(false)
+}
+
+// expandNavigatorNode adds object fields of the node as children for the first time, and expands the node
+func expandNavigatorNode(node *tview.TreeNode) {
+	ref := node.GetReference().(*navigatorNode)
+	if !ref.loaded {
+		ref.loaded = true
+		if kind := ref.doc.GetDocKind(); kind != nil {
+			for _, key := range kind.Keys() {
+				if sub := ref.doc.FindFieldDoc([]string{key}); sub != nil {
+					node.AddChild(newNavigatorTreeNode(sub, key, node))
+				}
+			}
+		}
+	}
+	node.SetText(strings.TrimPrefix(node.GetText(), "+ "))
+	node.Expand()
+}
+
+// InputHandler is override of Flex, which handles keyboard inputs.
+func (n *Navigator) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return n.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		if n.tree.HasFocus() {
+			node := n.tree.GetCurrentNode()
+			switch {
+			case event.Key() == tcell.KeyEscape:
+				setFocus(n.page)
+				return
+			case event.Key() == tcell.KeyRune && event.Rune() == 't':
+				n.SetShowTree(false)
+				setFocus(n.page)
+				return
+			case event.Key() == tcell.KeyRune && (event.Rune() == 'q' || event.Rune() == 'Q'):
+				if n.page.stopFn != nil {
+					n.page.stopFn()
+				}
+				return
+			case event.Key() == tcell.KeyRight || (event.Key() == tcell.KeyRune && event.Rune() == 'l'):
+				if node != nil {
+					expandNavigatorNode(node)
+				}
+				return
+			case event.Key() == tcell.KeyLeft || (event.Key() == tcell.KeyRune && event.Rune() == 'h'):
+				// collapse the node, or move to the parent like file trees
+				if node == nil {
+					return
+				}
+				if node.IsExpanded() && len(node.GetChildren()) > 0 {
+					node.Collapse()
+				} else if parent := node.GetReference().(*navigatorNode).parent; parent != nil {
+					n.tree.SetCurrentNode(parent)
+					n.openNode(parent)
+				}
+				return
+			}
+			n.tree.InputHandler()(event, setFocus)
+			return
+		}
+		if !n.page.typing() && event.Key() == tcell.KeyRune && event.Rune() == 't' {
+			n.SetShowTree(true)
+			n.opened = false
+			setFocus(n.tree)
+			return
+		}
+		n.page.InputHandler()(event, setFocus)
+	})
+}
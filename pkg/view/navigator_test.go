@@ -0,0 +1,49 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestNavigatorFollow(t *testing.T) {
+	page := NewPage(newTestDoc(t, "deployment.yaml", testDeploymentGVK))
+	n := NewNavigator(page)
+	if got := n.tree.GetCurrentNode().GetText(); got != "+ deployment" {
+		t.Errorf("current node = %q, want the root", got)
+	}
+
+	page.Open(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec", "template"))
+	node := n.tree.GetCurrentNode()
+	if got := node.GetReference().(*navigatorNode).doc.GetFullPath(); got != "deployment.spec.template" {
+		t.Errorf("current node = %q, want deployment.spec.template", got)
+	}
+	if got := childNames(node.GetReference().(*navigatorNode).parent); len(got) != 2 || got[0] != "selector" || got[1] != "template" {
+		t.Errorf("children of spec = %v, want object fields only", got)
+	}
+}
+
+func TestNavigatorRecursiveType(t *testing.T) {
+	n := NewNavigator(NewPage(newTestDoc(t, "recursive.yaml", testTreeGVK)))
+	node := n.tree.GetRoot()
+	// children of recursive types are added when expanding them
+	for _, name := range []string{"root", "children", "children", "children"} {
+		expandNavigatorNode(node)
+		children := node.GetChildren()
+		if len(children) != 1 || children[0].GetReference().(*navigatorNode).name != name {
+			t.Fatalf("children = %v, want %s", childNames(node), name)
+		}
+		node = children[0]
+	}
+	if node.GetChildren() != nil {
+		t.Errorf("children of a collapsed node = %v, want none", childNames(node))
+	}
+}
+
+func childNames(node *tview.TreeNode) []string {
+	names := []string{}
+	for _, child := range node.GetChildren() {
+		names = append(names, child.GetReference().(*navigatorNode).name)
+	}
+	return names
+}
@@ -0,0 +1,110 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// ObjectView shows an object in YAML, and the doc of the field under the cursor
+type ObjectView struct {
+	*tview.Flex
+	table   *tview.Table
+	docView *tview.TextView
+
+	schema proto.Schema
+	gvk    schema.GroupVersionKind
+	lines  []model.ObjectLine
+
+	selectFn func(doc *model.Doc)
+	stopFn   func()
+}
+
+// NewObjectView returns an ObjectView of obj, whose schema is s
+func NewObjectView(obj map[string]interface{}, s proto.Schema, gvk schema.GroupVersionKind) *ObjectView {
+	v := &ObjectView{
+		Flex:   tview.NewFlex().SetDirection(tview.FlexRow),
+		schema: s,
+		gvk:    gvk,
+		lines:  model.ObjectLines(obj, s),
+	}
+	v.table = tview.NewTable().SetSelectable(true, false)
+	v.table.SetSelectedStyle(highlightStyle)
+	v.table.SetBackgroundColor(plainColor)
+	for row, l := range v.lines {
+		v.table.SetCell(row, 0, tview.NewTableCell(tview.Escape(l.Text)))
+	}
+	v.table.SetSelectionChangedFunc(func(row, column int) { v.showDoc(row) })
+	v.table.SetSelectedFunc(func(row, column int) {
+		if doc, err := v.findDoc(row); err == nil && v.selectFn != nil {
+			v.selectFn(doc)
+		}
+	})
+
+	v.docView = tview.NewTextView().SetWrap(false)
+	v.docView.SetBorder(true).SetBorderPadding(0, 0, 1, 1).SetBackgroundColor(plainColor)
+
+	v.AddItem(v.table, 0, 1, true).
+		AddItem(v.docView, 0, 1, false)
+	v.showDoc(0)
+	return v
+}
+
+// SetSelectFn sets the callback, which is called when pressing Enter on a field.
+func (v *ObjectView) SetSelectFn(fn func(doc *model.Doc)) {
+	v.selectFn = fn
+}
+
+// SetStopFn sets the stop callback, which is called when pressing q/Q.
+func (v *ObjectView) SetStopFn(fn func()) {
+	v.stopFn = fn
+}
+
+func (v *ObjectView) findDoc(row int) (*model.Doc, error) {
+	var path []string
+	if row >= 0 && row < len(v.lines) {
+		path = v.lines[row].FieldsPath
+	}
+	return model.NewDoc(v.schema, path, v.gvk)
+}
+
+// showDoc shows the doc of the field in the row
+func (v *ObjectView) showDoc(row int) {
+	doc, err := v.findDoc(row)
+	if err != nil {
+		v.docView.SetTitle("")
+		v.docView.SetText(err.Error())
+		return
+	}
+	c := newLinesCalculator()
+	c.escape = false
+	calDocLines(c, doc, false)
+	v.docView.SetTitle(" " + tview.Escape(doc.GetFullPath()) + " ")
+	v.docView.SetText(strings.Join(c.lines, "\n")).ScrollToBeginning()
+}
+
+// InputHandler is override of Flex, which handles keyboard inputs.
+func (v *ObjectView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		switch {
+		case event.Key() == tcell.KeyTab:
+			// scroll the doc with moving keys
+			if v.table.HasFocus() {
+				setFocus(v.docView)
+			} else {
+				setFocus(v.table)
+			}
+			return
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'q' || event.Rune() == 'Q'):
+			if v.stopFn != nil {
+				v.stopFn()
+			}
+			return
+		}
+		v.Flex.InputHandler()(event, setFocus)
+	})
+}
@@ -1,7 +1,6 @@
 package view
 
 import (
-	"container/list"
 	"fmt"
 	"kexplain/pkg/model"
 	"regexp"
@@ -19,18 +18,36 @@ type Page struct {
 	version string
 	doc     *model.Doc
 	stopFn  func()
+	listFn  func()
+	openFn  func(doc *model.Doc)
+	// called when the doc is changed, like entering a field
+	changedFn func(doc *model.Doc)
+	// show the field tree instead of fields with descriptions
+	recursive bool
+	// show the YAML template instead of fields
+	template bool
+	// how fields marked deprecated or alpha are shown
+	stabilityFilter StabilityFilter
 
 	staticData *pageStaticData
 	pageData   *pageData
-	// Value is *pageData
-	// stores old pageData like browser history
-	// when going back to the parent field, pop the latest data
-	pageDataHistory *list.List
+	// visited docs like browser history, the current one is history[historyIdx]
+	history    []*historyEntry
+	historyIdx int
+	// list shown in place of lines like the history by `:history`, nil when it's not shown
+	list      *tview.List
+	listTitle string
+	// fuzzy finder of fields shown by Ctrl-T, nil when it's not shown
+	picker *fieldPicker
 
 	// Command
 	commandBar    *tview.InputField
 	typingCommand bool
 	command       string
+	// finder of docs for `:goto`, nil if not set
+	finder DocFinder
+	// message of the last command like an error or of a switched mode, cleared when pressing any key
+	message string
 
 	// searching
 	searchText string
@@ -53,6 +70,8 @@ type pageStaticData struct {
 	windowHeight int
 	// Y of fields, index is field index
 	fieldsY []int
+	// paths of fields relative to the doc, index is field index
+	fieldPaths [][]string
 	// lines slices
 	lines []string
 }
@@ -76,6 +95,10 @@ const versionPrefix = "VERSION:  "
 const resourcePrefix = "RESOURCE: "
 const descriptionLabel = "DESCRIPTION:"
 const fieldsLabel = "FIELDS:"
+const mergeSemanticsLabel = "MERGE SEMANTICS:"
+const fieldMergeSemanticsLabel = "Merge semantics:"
+const templateLabel = "TEMPLATE:"
+const featureGatesPrefix = "Feature gates: "
 
 const descIndent = 5
 const fieldIndent = 3
@@ -91,12 +114,12 @@ var highlightAndSearchStyle = tcell.StyleDefault.Background(tcell.ColorSeaGreen)
 // NewPage returns a Page
 func NewPage(doc *model.Doc) *Page {
 	page := &Page{
-		Box:             tview.NewBox().SetBackgroundColor(plainColor),
-		doc:             doc,
-		pageData:        &pageData{},
-		pageDataHistory: list.New(),
-		command:         ":",
-		staticData:      &pageStaticData{},
+		Box:        tview.NewBox().SetBackgroundColor(plainColor),
+		doc:        doc,
+		pageData:   &pageData{},
+		history:    []*historyEntry{{doc: doc}},
+		command:    ":",
+		staticData: &pageStaticData{},
 	}
 	commandBar := tview.NewInputField().
 		SetLabel("").
@@ -104,6 +127,7 @@ func NewPage(doc *model.Doc) *Page {
 		SetFieldWidth(0).
 		SetFieldBackgroundColor(plainColor).
 		SetDoneFunc(page.handleCommand)
+	commandBar.SetAutocompleteFunc(page.completeCommand)
 	page.commandBar = commandBar
 	page.calLines()
 	return page
@@ -114,6 +138,59 @@ func (p *Page) SetStopFn(fn func()) {
 	p.stopFn = fn
 }
 
+// SetListFn sets the callback, which is called when pressing Esc to go back to the list the page is opened from,
+// like the resource list.
+func (p *Page) SetListFn(fn func()) {
+	p.listFn = fn
+}
+
+// SetRecursive sets whether to show the field tree like `kubectl explain --recursive`.
+func (p *Page) SetRecursive(recursive bool) {
+	p.recursive = recursive
+	p.resetData()
+}
+
+// SetStabilityFilter sets how fields marked deprecated or alpha are shown, which is switched by pressing F.
+func (p *Page) SetStabilityFilter(filter StabilityFilter) {
+	p.stabilityFilter = filter
+	p.resetData()
+}
+
+// SetOpenFn sets the callback, which is called with the doc of the selected field when pressing o,
+// like opening it in the other pane of a SplitView.
+func (p *Page) SetOpenFn(fn func(doc *model.Doc)) {
+	p.openFn = fn
+}
+
+// SetChangedFn sets the callback, which is called with the new doc when the doc of the page is changed.
+func (p *Page) SetChangedFn(fn func(doc *model.Doc)) {
+	p.changedFn = fn
+}
+
+// Open shows the doc in the page as a new history entry
+func (p *Page) Open(doc *model.Doc) {
+	p.visit(doc)
+}
+
+// Replace shows the doc in the page in place of the current history entry
+func (p *Page) Replace(doc *model.Doc) {
+	p.history[p.historyIdx] = &historyEntry{doc: doc}
+	p.setDoc(doc)
+	p.resetData()
+}
+
+// GetDoc returns the doc shown in the page
+func (p *Page) GetDoc() *model.Doc {
+	return p.doc
+}
+
+func (p *Page) setDoc(doc *model.Doc) {
+	p.doc = doc
+	if p.changedFn != nil {
+		p.changedFn(doc)
+	}
+}
+
 func (p *Page) SetVersion(v string) {
 	p.version = v
 }
@@ -181,33 +258,54 @@ func (p *Page) Draw(screen tcell.Screen) {
 	if data.selectedField >= len(p.staticData.fieldsY) {
 		data.selectedField = len(p.staticData.fieldsY) - 1
 	}
+	// Y of the selected field, -1 if there are no fields like a doc of string
+	selectedY := -1
+	if data.selectedField >= 0 {
+		selectedY = fieldsY[data.selectedField]
+	}
 
 	dc := drawCtx{
 		screen: screen,
 		x:      x,
-		baseY:  data.currentY + y,
+		baseY:  data.currentY,
 		y:      0,
 		width:  width,
+		top:    y,
+		bottom: y + height - bottomHeight,
 	}
 
 	//// Draw header
-	dc.drawHorizontalLine(0, plainColor)
+	dc.drawHorizontalLine(y, plainColor)
 	title := p.doc.GetFullPath()
+	overlay := p.overlay()
+	if p.list != nil {
+		title = p.listTitle
+	} else if p.picker != nil {
+		title = "find field in " + strings.ToLower(p.doc.GetKind())
+	}
 	// 6 is 2 space + dash
-	if len(p.doc.GetFullPath())+8 > dc.width {
+	if len(title)+8 > dc.width {
 		fromIdx := len(title) - (width - 8 - 3) - 1
 		if fromIdx >= 0 && fromIdx < len(title) {
 			title = "..." + title[fromIdx:]
 		}
 	}
-	tview.Print(screen, " "+title+" ", 0, 0, dc.width, tview.AlignCenter, plainColor)
+	tview.Print(screen, " "+title+" ", x, y, dc.width, tview.AlignCenter, plainColor)
+
+	if overlay != nil {
+		overlay.SetRect(x, y+headerHeight, width, height-headerHeight-bottomHeight)
+		overlay.Draw(screen)
+	}
 
 	fieldIdx := 0
 	for i, l := range p.staticData.lines {
+		if overlay != nil {
+			break
+		}
 		drawY := dc.drawY()
 		dc.drawLineWithEscape(l, plainColor, false)
 		var selectedFieldLeft, selectedfieldLen int
-		if i == fieldsY[data.selectedField] {
+		if i == selectedY {
 			// highlight selected field
 			field, begin := findFirstField(l)
 			selectedFieldLeft = begin
@@ -217,13 +315,17 @@ func (p *Page) Draw(screen tcell.Screen) {
 		} else if fieldIdx < len(fieldsY) && i == fieldsY[fieldIdx] {
 			// highlight field
 			field, begin := findFirstField(l)
-			dc.overrideContent(field, begin, drawY, fieldStyle)
+			style := fieldStyle
+			if p.stabilityFilter == HighlightUnstable && hasUnstableBadge(l) {
+				style = unstableStyle
+			}
+			dc.overrideContent(field, begin, drawY, style)
 			fieldIdx++
 		}
 		if p.searchText != "" && searchRe != nil {
 			found := searchRe.FindAllStringIndex(l, -1)
 			for _, pair := range found {
-				if i == fieldsY[data.selectedField] {
+				if i == selectedY {
 					if pair[0] >= selectedFieldLeft && pair[0] < selectedFieldLeft+selectedfieldLen {
 						right := min(pair[1], selectedFieldLeft+selectedfieldLen)
 						dc.overrideContent(l[pair[0]:right], pair[0], drawY, highlightAndSearchStyle)
@@ -239,15 +341,71 @@ func (p *Page) Draw(screen tcell.Screen) {
 	}
 
 	//// Draw the command bar
-	p.commandBar.SetRect(x+1, height-1, width, 1)
+	bottomY := y + height - 1
+	p.commandBar.SetRect(x+1, bottomY, width-1, 1)
 	p.commandBar.Draw(screen)
-	tview.Print(dc.screen, p.command, x, height-1, 1, tview.AlignLeft, plainColor)
-	tview.Print(dc.screen, "("+p.version+")", x, height-1, dc.width, tview.AlignRight, plainColor)
+	tview.Print(dc.screen, p.command, x, bottomY, 1, tview.AlignLeft, plainColor)
+	if !p.typingCommand && p.message != "" {
+		tview.Print(dc.screen, tview.Escape(p.message), x+1, bottomY, dc.width-1, tview.AlignLeft, plainColor)
+	}
+	tview.Print(dc.screen, "("+p.version+")", x, bottomY, dc.width, tview.AlignRight, plainColor)
 	if !p.typingCommand {
-		screen.ShowCursor(x+1, height-1)
+		screen.ShowCursor(x+1, bottomY)
 	}
 }
 
+// newPageList returns a list shown by showList
+func newPageList() *tview.List {
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true).
+		SetSelectedBackgroundColor(tcell.ColorGreen).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetMainTextColor(plainColor).
+		SetSecondaryTextColor(plainColor)
+	list.SetBackgroundColor(plainColor)
+	return list
+}
+
+// showList shows the list in place of lines with the title,
+// whose items should set p.list to nil when selected to close it
+func (p *Page) showList(title string, list *tview.List) {
+	p.list = list
+	p.listTitle = title
+}
+
+// handleListInput moves in the list, Enter selects an item and Esc closes the list
+func (p *Page) handleListInput(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	switch {
+	case event.Key() == tcell.KeyEscape:
+		p.list = nil
+	case event.Key() == tcell.KeyRune && (event.Rune() == 'q' || event.Rune() == 'Q'):
+		p.list = nil
+	case event.Key() == tcell.KeyRune && event.Rune() == 'j':
+		p.list.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), setFocus)
+	case event.Key() == tcell.KeyRune && event.Rune() == 'k':
+		p.list.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), setFocus)
+	default:
+		p.list.InputHandler()(event, setFocus)
+	}
+}
+
+// typing returns true when keys are typed into the command bar or the field finder
+func (p *Page) typing() bool {
+	return p.typingCommand || p.picker != nil
+}
+
+// overlay returns the list shown in place of lines like the history, or nil
+func (p *Page) overlay() tview.Primitive {
+	if p.list != nil {
+		return p.list
+	}
+	if p.picker != nil {
+		return p.picker
+	}
+	return nil
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -257,7 +415,9 @@ func min(a, b int) int {
 
 // Focus is override of Box
 func (p *Page) Focus(delegate func(p tview.Primitive)) {
-	if p.typingCommand {
+	if p.picker != nil {
+		delegate(p.picker.input)
+	} else if p.typingCommand {
 		delegate(p.commandBar)
 	} else {
 		p.Box.Focus(delegate)
@@ -266,6 +426,9 @@ func (p *Page) Focus(delegate func(p tview.Primitive)) {
 
 // HasFocus is override of Box
 func (p *Page) HasFocus() bool {
+	if p.picker != nil {
+		return p.picker.input.HasFocus()
+	}
 	if p.typingCommand {
 		return p.commandBar.HasFocus()
 	}
@@ -274,70 +437,188 @@ func (p *Page) HasFocus() bool {
 
 func (p *Page) calLines() {
 	c := newLinesCalculator()
+	c.hideUnstable = p.stabilityFilter == HideUnstable
+	if p.template {
+		p.staticData.fieldsY, p.staticData.fieldPaths = nil, nil
+		calTemplateLines(c, p.doc)
+	} else {
+		p.staticData.fieldsY, p.staticData.fieldPaths = calDocLines(c, p.doc, p.recursive)
+	}
+	p.staticData.lines = c.lines
+}
+
+// calTemplateLines appends the YAML template of the doc
+func calTemplateLines(c *linesCalculator, doc *model.Doc) {
+	c.appendLine(kindPrefix + doc.GetKind())
+	c.appendLine(versionPrefix + doc.GetVersion())
+	c.appendLine("")
+	c.appendLine(templateLabel)
+	c.indent += fieldIndent
+	for _, l := range strings.Split(strings.TrimSuffix(doc.Template(model.DefaultTemplateDepth), "\n"), "\n") {
+		c.appendLine(l)
+	}
+	c.indent -= fieldIndent
+}
+
+// calDocLines appends lines of the doc, returns Y and paths of fields
+func calDocLines(c *linesCalculator, doc *model.Doc, recursive bool) ([]int, [][]string) {
+	stability := doc.GetStability()
 	// KIND
-	c.appendLine(kindPrefix + p.doc.GetKind())
+	kind := kindPrefix + doc.GetKind()
+	if len(doc.GetFieldsPath()) == 0 {
+		kind += badgeSuffix(stability)
+	}
+	c.appendLine(kind)
 	// VERSION
-	c.appendLine(versionPrefix + p.doc.GetVersion())
+	version := versionPrefix + doc.GetVersion()
+	if newer := doc.GetNewerVersion(); newer != "" {
+		version += " (deprecated, use " + newer + ")"
+	}
+	c.appendLine(version)
 	c.appendLine("")
 	// RESOURCE
-	resource := p.doc.GetFieldResource()
+	resource := doc.GetFieldResource()
 	if len(resource) > 0 {
-		c.appendLine(resourcePrefix + resource)
+		c.appendLine(resourcePrefix + resource + badgeSuffix(stability))
+		c.indent += len(resourcePrefix)
+		for _, detail := range doc.GetDetails() {
+			c.appendWrapped(detail)
+		}
+		if len(stability.FeatureGates) > 0 {
+			c.appendWrapped(featureGatesPrefix + strings.Join(stability.FeatureGates, ", "))
+		}
+		c.indent -= len(resourcePrefix)
 		c.appendLine("")
 	}
 	// DESCRIPTION
 	c.appendLine(descriptionLabel)
 	c.indent += descIndent
-	c.appendLines(p.doc.GetDescriptions())
+	c.appendLines(doc.GetDescriptions())
 	c.indent -= descIndent
 
+	// MERGE SEMANTICS
+	if lines := doc.GetMergeSemantics(); len(lines) > 0 {
+		c.appendLine("")
+		c.appendLine(mergeSemanticsLabel)
+		c.indent += descIndent
+		for _, line := range lines {
+			c.appendWrapped(line)
+		}
+		c.indent -= descIndent
+	}
+
 	//// Draw fields
 	c.appendLine("")
 	c.appendLine(fieldsLabel)
-	p.calFields(c)
-	p.staticData.lines = c.lines
+	if recursive {
+		return calFieldTree(c, doc)
+	}
+	return calFields(c, doc)
 }
 
-func (p *Page) calFields(c *linesCalculator) {
-	kind := p.doc.GetDocKind()
+func calFields(c *linesCalculator, doc *model.Doc) ([]int, [][]string) {
+	kind := doc.GetDocKind()
 	if kind == nil {
-		return
+		return nil, nil
 	}
-	data := p.staticData
-	fieldsLen := len(kind.Keys())
-	data.fieldsY = make([]int, fieldsLen)
+	fieldsY := []int{}
+	fieldPaths := [][]string{}
 	c.indent += fieldIndent
 	defer func() {
 		c.indent -= fieldIndent
 	}()
-	for i, key := range kind.Keys() {
+	for _, key := range kind.Keys() {
 		v := kind.Fields[key]
-		required := ""
-		if kind.IsRequired(key) {
-			required = " -required-"
+		stability := model.ParseStability(v.GetDescription())
+		if c.hideUnstable && stability.Unstable() {
+			continue
 		}
-
-		spaceLen := maxFieldWidth - len(key)
-		if spaceLen <= 0 {
-			spaceLen = 3
+		suffix := ""
+		if kind.IsRequired(key) {
+			suffix = " -required-"
 		}
+		suffix += badgeSuffix(stability)
 
-		data.fieldsY[i] = c.y
-		fieldLine := key + fmt.Sprintf("%s<%s>%s", strings.Repeat(" ", spaceLen), explain.GetTypeName(v), required)
-		c.appendLine(fieldLine)
+		fieldsY = append(fieldsY, c.y)
+		fieldPaths = append(fieldPaths, []string{key})
+		c.appendLine(fieldLine(key, explain.GetTypeName(v), suffix))
 
 		c.indent += fieldDescIndent
+		for _, detail := range model.SchemaDetails(v) {
+			c.appendWrapped(detail)
+		}
+		if len(stability.FeatureGates) > 0 {
+			c.appendWrapped(featureGatesPrefix + strings.Join(stability.FeatureGates, ", "))
+		}
+		if lines := model.MergeSemantics(v); len(lines) > 0 {
+			c.appendLine(fieldMergeSemanticsLabel)
+			c.indent += fieldDescIndent
+			for _, line := range lines {
+				c.appendWrapped(line)
+			}
+			c.indent -= fieldDescIndent
+		}
 		c.appendWrapped(v.GetDescription())
 		c.indent -= fieldDescIndent
 		c.appendLine("")
 	}
+	return fieldsY, fieldPaths
+}
+
+// calFieldTree appends fields and their fields recursively without descriptions
+func calFieldTree(c *linesCalculator, doc *model.Doc) ([]int, [][]string) {
+	nodes := doc.GetFieldTree()
+	if nodes == nil {
+		return nil, nil
+	}
+	fieldsY := []int{}
+	fieldPaths := [][]string{}
+	var appendNodes func(nodes []*model.FieldNode, path []string)
+	appendNodes = func(nodes []*model.FieldNode, path []string) {
+		c.indent += fieldIndent
+		defer func() {
+			c.indent -= fieldIndent
+		}()
+		for _, node := range nodes {
+			stability := model.ParseStability(node.Description)
+			if c.hideUnstable && stability.Unstable() {
+				continue
+			}
+			nodePath := append(append([]string{}, path...), node.Name)
+			suffix := ""
+			if node.Required {
+				suffix = " -required-"
+			}
+			suffix += badgeSuffix(stability)
+			if node.Recursive {
+				suffix += " (recursive)"
+			}
+			fieldsY = append(fieldsY, c.y)
+			fieldPaths = append(fieldPaths, nodePath)
+			c.appendLine(fieldLine(node.Name, node.Type, suffix))
+			appendNodes(node.Fields, nodePath)
+		}
+	}
+	appendNodes(nodes, nil)
+	return fieldsY, fieldPaths
+}
+
+// fieldLine returns line like `name        <string> -required-`
+func fieldLine(name, typeName, suffix string) string {
+	spaceLen := maxFieldWidth - len(name)
+	if spaceLen <= 0 {
+		spaceLen = 3
+	}
+	return name + fmt.Sprintf("%s<%s>%s", strings.Repeat(" ", spaceLen), typeName, suffix)
 }
 
 // InputHandler is override of Box, which handles keyboard inputs.
 func (p *Page) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
 	return p.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		// the page is replaced by the list when leaving, so it shouldn't take the focus back
+		leaving := false
 		defer func() {
-			if !p.typingCommand {
+			if !p.typingCommand && !leaving {
 				setFocus(p)
 			}
 		}()
@@ -345,16 +626,38 @@ func (p *Page) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			// Pass event on to child primitive.
 			if p.commandBar != nil && p.commandBar.HasFocus() {
 				currText := p.commandBar.GetText()
-				if handler := p.commandBar.InputHandler(); handler != nil {
-					handler(event, setFocus)
+				if event.Key() == tcell.KeyEnter && p.command == ":" {
+					// run the typed command instead of selecting the completion, which can be selected by Tab
+					p.handleCommand(tcell.KeyEnter)
+				} else {
+					if handler := p.commandBar.InputHandler(); handler != nil {
+						handler(event, setFocus)
+					}
+					// update completions of the completed text
+					if event.Key() == tcell.KeyTab {
+						p.commandBar.Autocomplete()
+					}
+					// Exit inputting when backspace and current text is empty like what `less` does
+					if currText == "" && (event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2) {
+						p.doneCommandTyping()
+					}
 				}
-				// Exit inputting when backspace and current text is empty like what `less` does
-				if currText == "" && (event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2) {
-					p.doneCommandTyping()
+				// close completions, which can't be done in the done func of the command bar locking them
+				if !p.typingCommand {
+					p.commandBar.Autocomplete()
 				}
 				return
 			}
 		}
+		p.message = ""
+		if p.picker != nil {
+			p.picker.handleInput(event, setFocus)
+			return
+		}
+		if p.list != nil {
+			p.handleListInput(event, setFocus)
+			return
+		}
 		data := p.pageData
 		upFn := func(size int) {
 			data.currentY -= size
@@ -366,27 +669,16 @@ func (p *Page) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 			data.currentY += size
 			// Hitting the bottom is handled in Draw
 		}
-		goBackFn := func() {
-			newDoc := p.doc.FindParentDoc()
-			if newDoc == nil {
-				return
-			}
-			p.doc = newDoc
-			if p.pageDataHistory.Len() == 0 {
-				p.resetData()
-			} else {
-				p.pageData = p.pageDataHistory.Remove(p.pageDataHistory.Back()).(*pageData)
-				p.calLines()
+		enterFieldFn := func() {
+			if newDoc := p.selectedDoc(); newDoc != nil {
+				p.visit(newDoc)
 			}
 		}
-		enterFieldFn := func() {
-			newDoc := p.doc.FindSubDoc(data.selectedField)
-			if newDoc == nil {
-				return
+		// go forward in the history, or enter the selected field if it's the latest one
+		forwardFn := func() {
+			if !p.goForward() {
+				enterFieldFn()
 			}
-			p.doc = newDoc
-			p.pageDataHistory.PushBack(p.pageData)
-			p.resetData()
 		}
 		switch event.Key() {
 		case tcell.KeyUp, tcell.KeyCtrlP:
@@ -431,9 +723,24 @@ func (p *Page) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 				}
 			case 'q', 'Q':
 				p.stopFn()
-			case '/':
+			case 'r':
+				p.recursive = !p.recursive
+				p.template = false
+				p.resetData()
+			case 'y':
+				p.template = !p.template
+				p.resetData()
+			case 'F':
+				p.switchStabilityFilter()
+			case 'u':
+				p.showUsedBy()
+			case 'o':
+				if doc := p.selectedDoc(); doc != nil && p.openFn != nil {
+					p.openFn(doc)
+				}
+			case '/', ':':
 				p.typingCommand = true
-				p.command = "/"
+				p.command = string(event.Rune())
 				setFocus(p.commandBar)
 			case 'n':
 				if p.searchText == "" {
@@ -447,27 +754,44 @@ func (p *Page) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.
 				p.searching = searchBack
 			case '[':
 				if pressAlt(event) {
-					goBackFn()
+					p.goBack()
 				}
 			case ']':
 				if pressAlt(event) {
-					enterFieldFn()
+					forwardFn()
 				}
 			}
 		case tcell.KeyLeft:
 			if pressAlt(event) {
-				goBackFn()
+				p.goBack()
 			}
 		case tcell.KeyRight:
 			if pressAlt(event) {
-				enterFieldFn()
+				forwardFn()
 			}
 		case tcell.KeyEnter:
 			enterFieldFn()
+		case tcell.KeyCtrlT:
+			p.showPicker()
+		case tcell.KeyEscape:
+			if p.listFn != nil {
+				leaving = true
+				p.listFn()
+			}
 		}
 	})
 }
 
+// selectedDoc returns the doc of the selected field, or nil if it has no fields.
+// Fields are the shown ones, which may be filtered by stability, and there's none in the template.
+func (p *Page) selectedDoc() *model.Doc {
+	idx := p.pageData.selectedField
+	if idx < 0 || idx >= len(p.staticData.fieldPaths) {
+		return nil
+	}
+	return p.doc.FindFieldDoc(p.staticData.fieldPaths[idx])
+}
+
 func (p *Page) resetData() {
 	p.pageData = &pageData{}
 	p.calLines()
@@ -479,7 +803,9 @@ func (p *Page) handleCommand(key tcell.Key) {
 	// we only handle Enter and Escape
 	case tcell.KeyEnter, tcell.KeyEscape:
 		// Only Enter means confirm the input
-		if key == tcell.KeyEnter {
+		if key == tcell.KeyEnter && p.command == ":" {
+			p.runCommand(p.commandBar.GetText())
+		} else if key == tcell.KeyEnter {
 			p.searchText = p.commandBar.GetText()
 			if p.searchText != "" {
 				p.searching = searchNext
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newTestPage(t *testing.T) *Page {
+	t.Helper()
+	return NewPage(newTestDoc(t, "widget.yaml", schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Widget"}))
+}
+
+func TestSelectedDoc(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    StabilityFilter
+		recursive bool
+		template  bool
+		selected  int
+		// nil if no doc is selected
+		want []string
+	}{
+		{name: "first field", selected: 0, want: []string{"legacySpec"}},
+		{name: "primitive field", selected: 1},
+		{name: "unstable fields hidden", filter: HideUnstable, selected: 1, want: []string{"spec"}},
+		{name: "recursive", recursive: true, selected: 3, want: []string{"spec"}},
+		{name: "recursive primitive field", recursive: true, selected: 1},
+		{name: "recursive and unstable fields hidden", filter: HideUnstable, recursive: true, selected: 1, want: []string{"spec"}},
+		{name: "template", template: true, selected: 0},
+		{name: "out of range", selected: 3},
+		{name: "out of range of shown fields", filter: HideUnstable, selected: 2},
+		{name: "negative", selected: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPage(t)
+			p.template = tt.template
+			p.recursive = tt.recursive
+			p.SetStabilityFilter(tt.filter)
+			p.pageData.selectedField = tt.selected
+			got := p.selectedDoc()
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("selectedDoc() = %v, want nil", got.GetFieldsPath())
+				}
+				return
+			}
+			if got == nil || !reflect.DeepEqual(got.GetFieldsPath(), tt.want) {
+				t.Errorf("selectedDoc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const maxPickerItems = 200
+
+// fieldPicker is a fuzzy finder of field paths of a resource, which is opened by Ctrl-T in a Page
+type fieldPicker struct {
+	*tview.Flex
+	input *tview.InputField
+	list  *tview.List
+
+	paths []string
+	// paths matching the input, the best one first
+	shown []string
+
+	selectFn func(path string)
+	closeFn  func()
+}
+
+// newFieldPicker returns a fieldPicker of paths like `pod.spec.containers`
+func newFieldPicker(paths []string) *fieldPicker {
+	f := &fieldPicker{
+		Flex:  tview.NewFlex().SetDirection(tview.FlexRow),
+		paths: paths,
+	}
+	f.input = tview.NewInputField().
+		SetLabel("> ").
+		SetPlaceholder("type to find fields, Enter to go to one, Esc to close").
+		SetFieldWidth(0).
+		SetFieldBackgroundColor(plainColor).
+		SetChangedFunc(func(text string) { f.update(text) })
+	f.list = tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true).
+		SetSelectedBackgroundColor(tcell.ColorGreen).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetMainTextColor(plainColor)
+	f.list.SetBackgroundColor(plainColor)
+
+	f.AddItem(f.input, 1, 0, true).
+		AddItem(f.list, 0, 1, false)
+	f.update("")
+	return f
+}
+
+// update shows paths matching the text, the best match first
+func (f *fieldPicker) update(text string) {
+	type match struct {
+		path      string
+		score     int
+		positions []int
+	}
+	matches := []match{}
+	for _, path := range f.paths {
+		if score, positions := model.FuzzyMatch(text, path); positions != nil {
+			matches = append(matches, match{path: path, score: score, positions: positions})
+		}
+	}
+	if text != "" {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].score > matches[j].score
+		})
+	}
+	if len(matches) > maxPickerItems {
+		matches = matches[:maxPickerItems]
+	}
+
+	f.list.Clear()
+	f.shown = f.shown[:0]
+	for _, m := range matches {
+		f.shown = append(f.shown, m.path)
+		f.list.AddItem(highlightRunes(m.path, m.positions), "", 0, nil)
+	}
+}
+
+// highlightRunes returns s with tags coloring runes at positions
+func highlightRunes(s string, positions []int) string {
+	matched := map[int]bool{}
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+	b := strings.Builder{}
+	for i, r := range []rune(s) {
+		if matched[i] {
+			b.WriteString("[yellow]" + tview.Escape(string(r)) + "[-]")
+		} else {
+			b.WriteString(tview.Escape(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// handleInput moves in the list by Up/Down/Ctrl-P/Ctrl-N, selects by Enter and closes by Esc,
+// other keys are typed into the input
+func (f *fieldPicker) handleInput(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyCtrlP, tcell.KeyCtrlN:
+		key := event.Key()
+		switch key {
+		case tcell.KeyCtrlP:
+			key = tcell.KeyUp
+		case tcell.KeyCtrlN:
+			key = tcell.KeyDown
+		}
+		f.list.InputHandler()(tcell.NewEventKey(key, 0, tcell.ModNone), setFocus)
+	case tcell.KeyEnter:
+		idx := f.list.GetCurrentItem()
+		if idx >= 0 && idx < len(f.shown) && f.selectFn != nil {
+			f.selectFn(f.shown[idx])
+		}
+	case tcell.KeyEscape:
+		if f.closeFn != nil {
+			f.closeFn()
+		}
+	default:
+		f.input.InputHandler()(event, setFocus)
+	}
+}
+
+// showPicker shows the fuzzy finder of all fields of the resource of the page
+func (p *Page) showPicker() {
+	kind := strings.ToLower(p.doc.GetKind())
+	paths := []string{kind}
+	for _, path := range p.doc.FindRootDoc().GetFieldPaths() {
+		paths = append(paths, kind+"."+strings.Join(path, "."))
+	}
+	picker := newFieldPicker(paths)
+	picker.selectFn = func(path string) {
+		p.picker = nil
+		doc, err := p.doc.FindPathDoc(strings.Split(path, ".")[1:])
+		if err != nil {
+			p.message = err.Error()
+			return
+		}
+		p.visit(doc)
+	}
+	picker.closeFn = func() {
+		p.picker = nil
+	}
+	p.picker = picker
+}
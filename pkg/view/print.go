@@ -0,0 +1,46 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"kexplain/pkg/model"
+	"strings"
+	"text/tabwriter"
+)
+
+// PrintDoc writes the doc as plain text like `kubectl explain`, wrapping text in width.
+// 0 width means no wrapping. Fields are printed as a tree if recursive is true.
+// Fields marked deprecated or alpha are not printed if filter is HideUnstable.
+func PrintDoc(w io.Writer, doc *model.Doc, width int, recursive bool, filter StabilityFilter) error {
+	c := newLinesCalculator()
+	c.wrap = width
+	c.escape = false
+	c.hideUnstable = filter == HideUnstable
+	calDocLines(c, doc, recursive)
+	for _, l := range c.lines {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(l, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintBrowserItems writes items as a table like `kubectl api-resources`
+func PrintBrowserItems(w io.Writer, items []BrowserItem) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(browserHeaders, "\t"))
+	for _, item := range items {
+		fmt.Fprintln(tw, strings.Join(item.columns(), "\t"))
+	}
+	return tw.Flush()
+}
+
+// PrintSearchResults prints results of searching fields like `kexplain search`
+func PrintSearchResults(w io.Writer, results []*model.SearchResult) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tAPIVERSION\tDESCRIPTION")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Path, r.GroupVersion(), r.Snippet)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,123 @@
+package view
+
+import (
+	"bytes"
+	"kexplain/pkg/model"
+	"kexplain/pkg/testutil"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	testDeploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	testTreeGVK       = schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Tree"}
+)
+
+// newTestDoc returns the doc of a kind in a swagger fixture in testdata
+func newTestDoc(t *testing.T, name string, gvk schema.GroupVersionKind, fieldsPath ...string) *model.Doc {
+	t.Helper()
+	resources, err := model.NewResources(testutil.Document(t, name))
+	if err != nil {
+		t.Fatalf("fail to create resources: %v", err)
+	}
+	doc, err := model.NewDoc(resources.LookupResource(gvk), fieldsPath, gvk)
+	if err != nil {
+		t.Fatalf("fail to get doc: %v", err)
+	}
+	return doc
+}
+
+func TestPrintDoc(t *testing.T) {
+	doc := newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec")
+	var out bytes.Buffer
+	if err := PrintDoc(&out, doc, 0, false, ShowUnstable); err != nil {
+		t.Fatalf("PrintDoc() error = %v", err)
+	}
+	for _, want := range []string{
+		"KIND:     Deployment\n",
+		"RESOURCE: spec <Object>\n",
+		"   replicas       <integer>\n     Format: int32\n     Number of desired pods. Defaults to 1.\n",
+		"   selector       <Object> -required-\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("PrintDoc() = %q, want it contains %q", out.String(), want)
+		}
+	}
+}
+
+func TestPrintDocRecursive(t *testing.T) {
+	doc := newTestDoc(t, "recursive.yaml", testTreeGVK)
+	var out bytes.Buffer
+	if err := PrintDoc(&out, doc, 0, true, ShowUnstable); err != nil {
+		t.Fatalf("PrintDoc() error = %v", err)
+	}
+	want := `FIELDS:
+   root           <Object>
+      children       <[]Object> (recursive)
+      name           <string> -required-
+`
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("PrintDoc() = %q, want it contains %q", out.String(), want)
+	}
+}
+
+func TestPrintBrowserItems(t *testing.T) {
+	namespaced := true
+	items := []BrowserItem{
+		{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, Plural: "pods", ShortNames: []string{"po"}, Namespaced: &namespaced},
+		{GVK: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, Plural: "deployments"},
+	}
+	var out bytes.Buffer
+	if err := PrintBrowserItems(&out, items); err != nil {
+		t.Fatalf("PrintBrowserItems() error = %v", err)
+	}
+	want := `NAME          SHORTNAMES   APIVERSION   NAMESPACED   KIND
+pods          po           v1           true         Pod
+deployments                apps/v1                   Deployment
+`
+	if got := out.String(); got != want {
+		t.Errorf("PrintBrowserItems() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintSearchResults(t *testing.T) {
+	resources, err := model.NewResources(testutil.Document(t, "deployment.yaml"))
+	if err != nil {
+		t.Fatalf("fail to create resources: %v", err)
+	}
+	var out bytes.Buffer
+	if err := PrintSearchResults(&out, model.NewResourcesSearchIndex(resources).Search("replicas")); err != nil {
+		t.Fatalf("PrintSearchResults() error = %v", err)
+	}
+	want := `PATH                       APIVERSION   DESCRIPTION
+deployment.spec.replicas   apps/v1      Number of desired pods.
+deployment                 apps/v1      ...nables declarative updates for Pods and ReplicaSets.
+`
+	if got := out.String(); got != want {
+		t.Errorf("PrintSearchResults() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintDocStabilityFilter(t *testing.T) {
+	doc := newTestDoc(t, "widget.yaml", schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Widget"})
+	tests := []struct {
+		filter StabilityFilter
+		want   bool
+	}{
+		{filter: ShowUnstable, want: true},
+		{filter: HideUnstable, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter.String(), func(t *testing.T) {
+			var out bytes.Buffer
+			if err := PrintDoc(&out, doc, 0, false, tt.filter); err != nil {
+				t.Fatalf("PrintDoc() error = %v", err)
+			}
+			if got := strings.Contains(out.String(), "legacySpec"); got != tt.want {
+				t.Errorf("PrintDoc() = %q, shows deprecated legacySpec %v, want %v", out.String(), got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SplitView hosts one Page, or two Pages side by side after opening a field in the other pane.
+// Each page has its own history.
+type SplitView struct {
+	*tview.Flex
+	pages []*Page
+	// navigators of pages
+	navigators []*Navigator
+	// index of the focused page
+	focused int
+	stopFn  func()
+	version string
+	// finders of docs for `:goto` by versions of pages
+	finders map[string]DocFinder
+	// pages are stacked instead of side by side
+	stacked bool
+}
+
+// NewSplitView returns a SplitView with a page of doc
+func NewSplitView(doc *model.Doc) *SplitView {
+	v := &SplitView{Flex: tview.NewFlex(), finders: map[string]DocFinder{}}
+	v.addPage(doc)
+	return v
+}
+
+// SetStopFn sets the stop callback, which is called when pressing q/Q in pages.
+func (v *SplitView) SetStopFn(fn func()) {
+	v.stopFn = fn
+	for _, p := range v.pages {
+		p.SetStopFn(fn)
+	}
+}
+
+// SetVersion sets the version of the first page, and pages opened later.
+func (v *SplitView) SetVersion(version string) {
+	v.version = version
+	v.pages[0].SetVersion(version)
+	v.pages[0].SetDocFinder(v.finders[version])
+}
+
+// SetDocFinder sets the finder of `:goto` for pages of the version.
+func (v *SplitView) SetDocFinder(version string, finder DocFinder) {
+	v.finders[version] = finder
+	for _, p := range v.pages {
+		if p.version == version {
+			p.SetDocFinder(finder)
+		}
+	}
+}
+
+// SetRecursive sets whether pages show the field tree.
+func (v *SplitView) SetRecursive(recursive bool) {
+	for _, p := range v.pages {
+		p.SetRecursive(recursive)
+	}
+}
+
+// SetStabilityFilter sets how pages show fields marked deprecated or alpha.
+func (v *SplitView) SetStabilityFilter(filter StabilityFilter) {
+	for _, p := range v.pages {
+		p.SetStabilityFilter(filter)
+	}
+}
+
+// Split shows doc in the other pane, which is created if there is only one page.
+// version is shown in the other pane, like a different k8s version.
+func (v *SplitView) Split(doc *model.Doc, version string) {
+	other := 1 - v.focused
+	if other >= len(v.pages) {
+		v.addPage(doc)
+	} else {
+		v.pages[other].Open(doc)
+	}
+	v.pages[other].SetVersion(version)
+	v.pages[other].SetDocFinder(v.finders[version])
+}
+
+func (v *SplitView) addPage(doc *model.Doc) {
+	page := NewPage(doc)
+	page.SetStopFn(v.stopFn)
+	page.SetVersion(v.version)
+	page.SetDocFinder(v.finders[v.version])
+	page.SetOpenFn(func(doc *model.Doc) { v.Split(doc, page.version) })
+	if len(v.pages) > 0 {
+		v.AddItem(nil, 1, 0, false)
+		page.SetRecursive(v.pages[0].recursive)
+		page.SetStabilityFilter(v.pages[0].stabilityFilter)
+	}
+	navigator := NewNavigator(page)
+	v.pages = append(v.pages, page)
+	v.navigators = append(v.navigators, navigator)
+	v.AddItem(navigator, 0, 1, len(v.pages) == 1)
+}
+
+// InputHandler is override of Flex, which handles keyboard inputs.
+func (v *SplitView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		page := v.pages[v.focused]
+		if !page.typing() {
+			switch {
+			case event.Key() == tcell.KeyCtrlW:
+				v.focused = (v.focused + 1) % len(v.pages)
+				setFocus(v.navigators[v.focused])
+				return
+			case event.Key() == tcell.KeyRune && event.Rune() == 'S':
+				// switch between vertical and horizontal split
+				v.stacked = !v.stacked
+				if v.stacked {
+					v.SetDirection(tview.FlexRow)
+				} else {
+					v.SetDirection(tview.FlexColumn)
+				}
+				return
+			}
+		}
+		v.navigators[v.focused].InputHandler()(event, setFocus)
+	})
+}
@@ -0,0 +1,30 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestSplitView(t *testing.T) {
+	v := NewSplitView(newTestDoc(t, "deployment.yaml", testDeploymentGVK))
+	v.SetVersion("v1.23")
+
+	v.Split(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec"), "v1.24")
+	if len(v.pages) != 2 || v.pages[1].doc.GetFullPath() != "deployment.spec" || v.pages[1].version != "v1.24" {
+		t.Fatalf("pages after Split() = %v, want deployment.spec of v1.24 in the other pane", v.pages)
+	}
+
+	var focused tview.Primitive
+	v.InputHandler()(tcell.NewEventKey(tcell.KeyCtrlW, 0, tcell.ModNone), func(p tview.Primitive) { focused = p })
+	if v.focused != 1 || focused != v.navigators[1] {
+		t.Errorf("focused = %d, want the other pane after Ctrl-W", v.focused)
+	}
+
+	// the other pane of the second page is the first page
+	v.Split(newTestDoc(t, "deployment.yaml", testDeploymentGVK, "spec", "template"), "v1.25")
+	if len(v.pages) != 2 || v.pages[0].doc.GetFullPath() != "deployment.spec.template" || v.pages[0].version != "v1.25" {
+		t.Errorf("pages after the second Split() = %v, want deployment.spec.template of v1.25 in the first pane", v.pages)
+	}
+}
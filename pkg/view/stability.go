@@ -0,0 +1,64 @@
+package view
+
+import (
+	"kexplain/pkg/model"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// StabilityFilter is how fields marked deprecated or alpha are shown
+type StabilityFilter int8
+
+const (
+	// ShowUnstable shows them with badges like other fields
+	ShowUnstable StabilityFilter = iota
+	// HighlightUnstable shows them in red
+	HighlightUnstable
+	// HideUnstable doesn't show them
+	HideUnstable
+)
+
+var stabilityFilterNames = []string{"show", "highlight", "hide"}
+
+func (f StabilityFilter) String() string {
+	return stabilityFilterNames[f]
+}
+
+// StabilityFilterNames returns names of filters for flags like `hide`
+func StabilityFilterNames() []string {
+	return append([]string{}, stabilityFilterNames...)
+}
+
+// ParseStabilityFilter returns the filter of the name like `hide`, false if it's unknown
+func ParseStabilityFilter(name string) (StabilityFilter, bool) {
+	for i, n := range stabilityFilterNames {
+		if n == name {
+			return StabilityFilter(i), true
+		}
+	}
+	return ShowUnstable, false
+}
+
+var unstableStyle = tcell.StyleDefault.Foreground(tcell.ColorRed)
+
+// badgeSuffix returns badges of the stability like ` -deprecated- -alpha-`
+func badgeSuffix(stability *model.Stability) string {
+	suffix := ""
+	for _, badge := range stability.Badges() {
+		suffix += " -" + badge + "-"
+	}
+	return suffix
+}
+
+// hasUnstableBadge returns true if the field line has the badge of deprecated or alpha
+func hasUnstableBadge(line string) bool {
+	return strings.Contains(line, " -deprecated-") || strings.Contains(line, " -alpha-")
+}
+
+// switchStabilityFilter switches showing, highlighting and hiding fields marked deprecated or alpha
+func (p *Page) switchStabilityFilter() {
+	p.stabilityFilter = (p.stabilityFilter + 1) % StabilityFilter(len(stabilityFilterNames))
+	p.message = "deprecated and alpha fields: " + p.stabilityFilter.String()
+	p.resetData()
+}